@@ -0,0 +1,588 @@
+// Package bridge forwards VMC messages from UDP to browsers over WebSocket.
+//
+// A Bridge receives OSC packets, unpacks any bundles, and sends each message to all WebSocket
+// clients, that subscribed to its address. Clients connect through the bridge's HTTP handler, and
+// choose their subscriptions and format with query parameters:
+//
+//	ws://localhost:8080/?format=json&subscribe=/VMC/Ext/Bone/Pos&subscribe=/VMC/Ext/Blend/*
+//
+// Subscriptions are OSC address patterns, as supported by osc.MatchAddress. Clients without any
+// subscription receive all messages. They can be changed later on, by sending text messages:
+//
+//	{"subscribe": ["/VMC/Ext/Root/Pos"], "unsubscribe": ["/VMC/Ext/Blend/*"]}
+//
+// A client can have up to 64 subscriptions. Handshakes with more are rejected, and commands that
+// exceed the limit close the connection.
+//
+// Each client has a queue of pending messages. If a client can't keep up and its queue is full,
+// further messages are dropped for that client, so slow clients never stall the receiver or other
+// clients.
+package bridge
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/dnaka91/go-vmcparser/transport"
+)
+
+// Default values, that are used for zero or negative values in the Config.
+const (
+	DefaultQueueSize    = 256
+	DefaultWriteTimeout = 5 * time.Second
+)
+
+// Limits of the bridge.
+const (
+	// maxPacketSize is the largest possible UDP payload.
+	maxPacketSize = 65535
+	// maxCommandSize is the size limit of messages, that clients send to the bridge.
+	maxCommandSize = 4096
+	// maxPatterns is the maximum amount of subscriptions of a single client, as each one adds to
+	// the cost of every published message.
+	maxPatterns = 64
+)
+
+// Format is the encoding of the messages, that are sent to a client.
+type Format uint8
+
+// Possible formats.
+const (
+	// FormatOSC sends each message as binary WebSocket message, in its original OSC encoding.
+	FormatOSC Format = iota
+	// FormatJSON sends each message as text WebSocket message, containing a JSON object with the
+	// address and the arguments, like {"address": "/VMC/Ext/Blend/Val", "args": ["Joy", 1]}.
+	FormatJSON
+)
+
+var _ fmt.Stringer = (*Format)(nil)
+
+// String returns the name of the format, as used in the format query parameter.
+func (f Format) String() string {
+	switch f {
+	case FormatOSC:
+		return "osc"
+	case FormatJSON:
+		return "json"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(f))
+	}
+}
+
+func parseFormat(name string) (Format, bool) {
+	switch name {
+	case "osc":
+		return FormatOSC, true
+	case "json":
+		return FormatJSON, true
+	default:
+		return 0, false
+	}
+}
+
+// Config configures the bridge.
+type Config struct {
+	// Format is the format for clients, that don't select one with the format query parameter.
+	Format Format
+	// QueueSize is the amount of messages, that are buffered for each client. If zero or
+	// negative, DefaultQueueSize is used.
+	QueueSize int
+	// WriteTimeout is the maximum time for sending a single message to a client, before the
+	// client is disconnected. If zero or negative, DefaultWriteTimeout is used.
+	WriteTimeout time.Duration
+}
+
+// Bridge forwards OSC packets to WebSocket clients. It's safe for concurrent use.
+type Bridge struct {
+	config Config
+	mu     sync.Mutex
+	// clients is replaced instead of modified, so publishing works on a snapshot, without holding
+	// the lock while matching subscriptions.
+	clients []*client
+	dropped uint64
+}
+
+// New creates a new bridge without any clients.
+func New(config Config) *Bridge {
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultQueueSize
+	}
+
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = DefaultWriteTimeout
+	}
+
+	return &Bridge{
+		config:  config,
+		mu:      sync.Mutex{},
+		clients: nil,
+		dropped: 0,
+	}
+}
+
+// Serve receives packets from the connection, and publishes them to all clients. Malformed packets
+// are skipped. It returns once receiving fails, like after the connection was closed.
+func (b *Bridge) Serve(conn transport.PacketConn) error {
+	buf := make([]byte, maxPacketSize)
+
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return fmt.Errorf("failed receiving packet: %w", err)
+		}
+
+		// A single misbehaving sender must not stop the bridge.
+		_ = b.Publish(buf[:n])
+	}
+}
+
+// Publish sends all messages of the packet to the subscribed clients. The packet isn't referenced
+// after the call returns.
+func (b *Bridge) Publish(packet []byte) error {
+	parsed, _, err := osc.ReadPacket(packet)
+	if err != nil {
+		return fmt.Errorf("failed parsing packet: %w", err)
+	}
+
+	b.mu.Lock()
+	clients := b.clients
+	b.mu.Unlock()
+
+	b.publish(parsed, clients)
+
+	return nil
+}
+
+func (b *Bridge) publish(packet *osc.Packet, clients []*client) {
+	if packet.Bundle != nil {
+		for i := range packet.Bundle.Contents {
+			b.publish(&packet.Bundle.Contents[i], clients)
+		}
+
+		return
+	}
+
+	// Each format is encoded at most once, and shared by all clients.
+	var payloads [2][]byte
+
+	for _, c := range clients {
+		if !c.subscribed(packet.Message.Address) {
+			continue
+		}
+
+		payload := payloads[c.format]
+		if payload == nil {
+			if payload = encode(packet.Message, c.format); payload == nil {
+				continue
+			}
+
+			payloads[c.format] = payload
+		}
+
+		select {
+		case c.queue <- payload:
+		default:
+			atomic.AddUint64(&b.dropped, 1)
+		}
+	}
+}
+
+// Clients returns the amount of connected clients.
+func (b *Bridge) Clients() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.clients)
+}
+
+// Dropped returns the total amount of messages, that were dropped due to full client queues.
+func (b *Bridge) Dropped() uint64 {
+	return atomic.LoadUint64(&b.dropped)
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection, and sends messages to it until the
+// client disconnects.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	accept, err := acceptKey(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	query := r.URL.Query()
+
+	format := b.config.Format
+	if name := query.Get("format"); name != "" {
+		var ok bool
+		if format, ok = parseFormat(name); !ok {
+			http.Error(w, fmt.Sprintf("unknown format %q", name), http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	// The client is fully set up before the handshake completes, so nothing can fail afterwards.
+	c := &client{
+		conn:     nil,
+		format:   format,
+		timeout:  b.config.WriteTimeout,
+		queue:    make(chan []byte, b.config.QueueSize),
+		done:     make(chan struct{}),
+		writeMu:  sync.Mutex{},
+		buf:      nil,
+		mu:       sync.Mutex{},
+		patterns: nil,
+	}
+
+	if err := c.subscribe(query["subscribe"], nil); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket upgrade not supported", http.StatusInternalServerError)
+
+		return
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	c.conn = conn
+
+	if _, err := conn.Write([]byte("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n")); err != nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.clients = append(b.clients[:len(b.clients):len(b.clients)], c)
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		b.clients = without(b.clients, c)
+		b.mu.Unlock()
+
+		close(c.done)
+	}()
+
+	go c.send()
+
+	c.receive(rw.Reader)
+}
+
+// without returns a copy of the clients, that doesn't contain the client.
+func without(clients []*client, c *client) []*client {
+	kept := make([]*client, 0, len(clients))
+
+	for _, other := range clients {
+		if other != c {
+			kept = append(kept, other)
+		}
+	}
+
+	return kept
+}
+
+// jsonMessage is the JSON encoding of a single message.
+type jsonMessage struct {
+	Address string        `json:"address"`
+	Args    []interface{} `json:"args"`
+}
+
+// encode converts the message into the payload for the format.
+func encode(msg *osc.Message, format Format) []byte {
+	if format == FormatOSC {
+		return append([]byte(nil), msg.Raw...)
+	}
+
+	args := make([]interface{}, len(msg.Arguments))
+
+	for i, arg := range msg.Arguments {
+		// Strings are readable text, while blobs are encoded as base64 by the JSON encoder.
+		switch msg.TypeTags[i] {
+		case osc.TypeTagString, osc.TypeTagSymbol:
+			args[i] = string(arg.([]byte))
+		case osc.TypeTagChar:
+			args[i] = string(arg.(rune))
+		case osc.TypeTagFloat:
+			args[i] = finite(float64(arg.(float32)))
+		case osc.TypeTagDouble:
+			args[i] = finite(arg.(float64))
+		default:
+			args[i] = arg
+		}
+	}
+
+	payload, err := json.Marshal(jsonMessage{Address: string(msg.Address), Args: args})
+	if err != nil {
+		return nil
+	}
+
+	return payload
+}
+
+// finite replaces infinite values and NaN with nil, as they can't be represented in JSON.
+func finite(value float64) interface{} {
+	if math.IsInf(value, 0) || math.IsNaN(value) {
+		return nil
+	}
+
+	return value
+}
+
+// client is a single WebSocket connection.
+type client struct {
+	conn    net.Conn
+	format  Format
+	timeout time.Duration
+	queue   chan []byte
+	done    chan struct{}
+
+	writeMu sync.Mutex
+	buf     []byte
+
+	mu       sync.Mutex
+	patterns []string
+}
+
+func (c *client) subscribed(address []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range c.patterns {
+		if osc.MatchAddress(pattern, address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// subscribe adds and removes patterns. The subscriptions stay unchanged, if they would exceed the
+// limit.
+func (c *client) subscribe(add, remove []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	patterns := make([]string, 0, len(c.patterns)+len(add))
+
+	for _, list := range [][]string{c.patterns, add} {
+		for _, pattern := range list {
+			if !contains(patterns, pattern) && !contains(remove, pattern) {
+				patterns = append(patterns, pattern)
+			}
+		}
+	}
+
+	if len(patterns) > maxPatterns {
+		return fmt.Errorf("%w: %d of at most %d", ErrTooManyPatterns, len(patterns), maxPatterns)
+	}
+
+	c.patterns = patterns
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// send writes all queued messages to the connection, until the client is gone.
+func (c *client) send() {
+	opcode := byte(opBinary)
+	if c.format == FormatJSON {
+		opcode = opText
+	}
+
+	for {
+		select {
+		case payload := <-c.queue:
+			if err := c.write(opcode, payload); err != nil {
+				// Closing the connection ends the receiving side as well.
+				c.conn.Close()
+
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *client) write(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(c.timeout)); err != nil {
+		return fmt.Errorf("failed setting write deadline: %w", err)
+	}
+
+	c.buf = appendFrame(c.buf[:0], opcode, payload)
+
+	if _, err := c.conn.Write(c.buf); err != nil {
+		return fmt.Errorf("failed writing frame: %w", err)
+	}
+
+	return nil
+}
+
+// receive handles the frames from the client, until the connection is closed.
+func (c *client) receive(r *bufio.Reader) {
+	var (
+		buf        []byte
+		message    []byte
+		text       bool
+		fragmented bool
+	)
+
+	for {
+		f, err := readFrame(r, buf[:0], maxCommandSize)
+		if err != nil {
+			c.close(err)
+
+			return
+		}
+
+		buf = f.payload
+
+		switch f.opcode {
+		case opPing:
+			_ = c.write(opPong, f.payload)
+		case opPong:
+		case opClose:
+			c.close(nil)
+
+			return
+		case opText, opBinary, opContinuation:
+			// Continuations are only allowed, and also required, after an unfinished message.
+			if (f.opcode == opContinuation) != fragmented {
+				c.close(ErrFragmentation)
+
+				return
+			}
+
+			if f.opcode != opContinuation {
+				text = f.opcode == opText
+				message = message[:0]
+			}
+
+			fragmented = !f.fin
+
+			if message = append(message, f.payload...); len(message) > maxCommandSize {
+				c.close(ErrFrameTooLarge)
+
+				return
+			}
+
+			if !f.fin {
+				continue
+			}
+
+			if text && !utf8.Valid(message) {
+				c.close(ErrInvalidUTF8)
+
+				return
+			}
+
+			if err := c.command(text, message); err != nil {
+				c.close(err)
+
+				return
+			}
+		default:
+			c.close(ErrUnknownOpcode)
+
+			return
+		}
+	}
+}
+
+// Errors of client commands.
+var (
+	ErrBinaryCommand   = errors.New("binary messages are not supported")
+	ErrInvalidCommand  = errors.New("invalid command")
+	ErrTooManyPatterns = errors.New("too many subscriptions")
+)
+
+// command changes the subscriptions, as requested by the client.
+func (c *client) command(text bool, message []byte) error {
+	if !text {
+		return ErrBinaryCommand
+	}
+
+	var cmd struct {
+		Subscribe   []string `json:"subscribe"`
+		Unsubscribe []string `json:"unsubscribe"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(message))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&cmd); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCommand, err)
+	}
+
+	return c.subscribe(cmd.Subscribe, cmd.Unsubscribe)
+}
+
+// close sends a close frame with a status code, that matches the error. Errors of the connection
+// itself are not reported, as the connection is already unusable.
+func (c *client) close(err error) {
+	var code uint16
+
+	switch {
+	case err == nil:
+		code = closeNormal
+	case errors.Is(err, ErrFrameTooLarge):
+		code = closeTooLarge
+	case errors.Is(err, ErrBinaryCommand):
+		code = closeUnsupported
+	case errors.Is(err, ErrInvalidCommand), errors.Is(err, ErrInvalidUTF8):
+		code = closeInvalidData
+	case errors.Is(err, ErrTooManyPatterns):
+		code = closePolicy
+	case errors.Is(err, ErrUnmaskedFrame), errors.Is(err, ErrInvalidControl),
+		errors.Is(err, ErrReservedBits), errors.Is(err, ErrUnknownOpcode),
+		errors.Is(err, ErrFragmentation):
+		code = closeProtocol
+	default:
+		return
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	_, _ = c.conn.Write(appendClose(nil, code))
+}
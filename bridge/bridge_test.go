@@ -0,0 +1,410 @@
+package bridge_test
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/bridge"
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/dnaka91/go-vmcparser/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// client is a minimal WebSocket client, that sends masked frames as required by the protocol.
+type client struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func connect(t *testing.T, b *bridge.Bridge, query string) *client {
+	t.Helper()
+
+	server := httptest.NewServer(b)
+	t.Cleanup(server.Close)
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	var key [16]byte
+	_, err = rand.Read(key[:])
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("GET /?" + query + " HTTP/1.1\r\n" +
+		"Host: localhost\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Sec-WebSocket-Version: 13\r\n" +
+		"Sec-WebSocket-Key: " + base64.StdEncoding.EncodeToString(key[:]) + "\r\n\r\n"))
+	require.NoError(t, err)
+
+	r := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(r, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	require.NotEmpty(t, resp.Header.Get("Sec-WebSocket-Accept"))
+
+	return &client{t: t, conn: conn, r: r}
+}
+
+func (c *client) write(opcode byte, payload []byte) {
+	c.t.Helper()
+	c.writeFrame(0x80|opcode, payload)
+}
+
+// writeFrame writes a frame with a raw first header byte, which holds the flags and the opcode.
+func (c *client) writeFrame(header byte, payload []byte) {
+	c.t.Helper()
+
+	mask := [4]byte{1, 2, 3, 4}
+	buf := []byte{header, 0x80 | byte(len(payload))}
+	buf = append(buf, mask[:]...)
+
+	for i, b := range payload {
+		buf = append(buf, b^mask[i%4])
+	}
+
+	_, err := c.conn.Write(buf)
+	require.NoError(c.t, err)
+}
+
+func (c *client) read() (byte, []byte) {
+	c.t.Helper()
+
+	require.NoError(c.t, c.conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	header := make([]byte, 2)
+	_, err := io.ReadFull(c.r, header)
+	require.NoError(c.t, err)
+
+	size := uint64(header[1] & 0x7f)
+
+	switch size {
+	case 126:
+		ext := make([]byte, 2)
+		_, err = io.ReadFull(c.r, ext)
+		require.NoError(c.t, err)
+
+		size = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		_, err = io.ReadFull(c.r, ext)
+		require.NoError(c.t, err)
+
+		size = binary.BigEndian.Uint64(ext)
+	}
+
+	payload := make([]byte, size)
+	_, err = io.ReadFull(c.r, payload)
+	require.NoError(c.t, err)
+
+	return header[0] & 0x0f, payload
+}
+
+// sync waits until the bridge handled all frames, that were sent before.
+func (c *client) sync() {
+	c.t.Helper()
+
+	c.write(0x9, []byte("sync"))
+
+	opcode, payload := c.read()
+	require.Equal(c.t, byte(0xa), opcode)
+	require.Equal(c.t, "sync", string(payload))
+}
+
+func waitClients(t *testing.T, b *bridge.Bridge, count int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool { return b.Clients() == count }, 5*time.Second, time.Millisecond)
+}
+
+func message(address string) []byte {
+	return osc.AppendMessage(nil, address, []byte("i"), osc.AppendInt(nil, 1))
+}
+
+func TestSubscribe(t *testing.T) {
+	b := bridge.New(bridge.Config{})
+	c := connect(t, b, "subscribe=/VMC/Ext/Blend/*&subscribe=/VMC/Ext/T")
+	waitClients(t, b, 1)
+
+	packet := osc.AppendBundle(nil, osc.TimeTagImmediate)
+	for _, address := range []string{"/VMC/Ext/Bone/Pos", "/VMC/Ext/Blend/Val", "/VMC/Ext/T"} {
+		address := address
+		packet = osc.AppendElement(packet, func(buf []byte) []byte {
+			return append(buf, message(address)...)
+		})
+	}
+
+	require.NoError(t, b.Publish(packet))
+
+	for _, want := range []string{"/VMC/Ext/Blend/Val", "/VMC/Ext/T"} {
+		opcode, payload := c.read()
+		assert.Equal(t, byte(0x2), opcode)
+		assert.Equal(t, message(want), payload)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	b := bridge.New(bridge.Config{Format: bridge.FormatJSON})
+	c := connect(t, b, "")
+	waitClients(t, b, 1)
+
+	args := osc.AppendString(nil, "Joy")
+	args = osc.AppendFloat(args, 0.5)
+	args = osc.AppendInt(args, 7)
+	args = osc.AppendChar(args, 'x')
+	args = osc.AppendFloat(args, float32(math.NaN()))
+	args = osc.AppendBlob(args, []byte{1, 2})
+
+	require.NoError(t, b.Publish(osc.AppendMessage(nil, "/VMC/Ext/Blend/Val", []byte("sficfbT"), args)))
+
+	opcode, payload := c.read()
+	assert.Equal(t, byte(0x1), opcode)
+	assert.JSONEq(t, `{"address":"/VMC/Ext/Blend/Val","args":["Joy",0.5,7,"x",null,"AQI=",true]}`, string(payload))
+}
+
+func TestCommands(t *testing.T) {
+	b := bridge.New(bridge.Config{})
+	c := connect(t, b, "format=osc&subscribe=/b")
+	waitClients(t, b, 1)
+
+	c.write(0x1, []byte(`{"subscribe":["/a"],"unsubscribe":["/b"]}`))
+	c.sync()
+
+	require.NoError(t, b.Publish(message("/b")))
+	require.NoError(t, b.Publish(message("/a")))
+
+	_, payload := c.read()
+	assert.Equal(t, message("/a"), payload)
+
+	c.write(0x1, []byte(`{"unknown":true}`))
+
+	opcode, payload := c.read()
+	assert.Equal(t, byte(0x8), opcode)
+	assert.Equal(t, []byte{0x03, 0xef}, payload)
+
+	waitClients(t, b, 0)
+}
+
+func TestTooManyPatterns(t *testing.T) {
+	b := bridge.New(bridge.Config{})
+
+	query := make([]string, 65)
+	for i := range query {
+		query[i] = fmt.Sprintf("subscribe=/%d", i)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?"+strings.Join(query, "&"), nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+
+	rec := httptest.NewRecorder()
+	b.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	c := connect(t, b, strings.Join(query[:64], "&"))
+	waitClients(t, b, 1)
+
+	// Replacing a subscription keeps the client at the limit.
+	c.write(0x1, []byte(`{"subscribe":["/a"],"unsubscribe":["/0"]}`))
+	c.sync()
+	c.write(0x1, []byte(`{"subscribe":["/b"]}`))
+
+	opcode, payload := c.read()
+	assert.Equal(t, byte(0x8), opcode)
+	assert.Equal(t, []byte{0x03, 0xf0}, payload)
+
+	waitClients(t, b, 0)
+}
+
+func TestFragmentedCommand(t *testing.T) {
+	b := bridge.New(bridge.Config{})
+	c := connect(t, b, "")
+	waitClients(t, b, 1)
+
+	// Control frames may be interleaved with the fragments of a message.
+	c.writeFrame(0x01, []byte(`{"subscribe":`))
+	c.sync()
+	c.writeFrame(0x80, []byte(`["/a"]}`))
+	c.sync()
+
+	require.NoError(t, b.Publish(message("/b")))
+	require.NoError(t, b.Publish(message("/a")))
+
+	_, payload := c.read()
+	assert.Equal(t, message("/a"), payload)
+}
+
+// rawFrame is a frame with a raw first header byte.
+type rawFrame struct {
+	header  byte
+	payload []byte
+}
+
+func TestProtocolErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		frames []rawFrame
+		code   []byte
+	}{
+		{
+			name:   "reserved bits",
+			frames: []rawFrame{{0xc1, []byte("{}")}},
+			code:   []byte{0x03, 0xea},
+		},
+		{
+			name:   "unknown opcode",
+			frames: []rawFrame{{0x83, nil}},
+			code:   []byte{0x03, 0xea},
+		},
+		{
+			name:   "continuation without start",
+			frames: []rawFrame{{0x80, []byte("{}")}},
+			code:   []byte{0x03, 0xea},
+		},
+		{
+			name:   "new message before the end",
+			frames: []rawFrame{{0x01, []byte("{")}, {0x81, []byte("}")}},
+			code:   []byte{0x03, 0xea},
+		},
+		{
+			name:   "invalid utf-8",
+			frames: []rawFrame{{0x81, []byte("{\"subscribe\":[\"\xff\"]}")}},
+			code:   []byte{0x03, 0xef},
+		},
+		{
+			name:   "binary command",
+			frames: []rawFrame{{0x82, []byte("{}")}},
+			code:   []byte{0x03, 0xeb},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			b := bridge.New(bridge.Config{})
+			c := connect(t, b, "")
+			waitClients(t, b, 1)
+
+			for _, f := range tt.frames {
+				c.writeFrame(f.header, f.payload)
+			}
+
+			opcode, payload := c.read()
+			assert.Equal(t, byte(0x8), opcode)
+			assert.Equal(t, tt.code, payload)
+
+			waitClients(t, b, 0)
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	b := bridge.New(bridge.Config{})
+	c := connect(t, b, "")
+	waitClients(t, b, 1)
+
+	c.write(0x8, []byte{0x03, 0xe8})
+
+	opcode, payload := c.read()
+	assert.Equal(t, byte(0x8), opcode)
+	assert.Equal(t, []byte{0x03, 0xe8}, payload)
+
+	waitClients(t, b, 0)
+}
+
+func TestBackpressure(t *testing.T) {
+	b := bridge.New(bridge.Config{QueueSize: 4})
+	connect(t, b, "subscribe=/slow")
+	fast := connect(t, b, "subscribe=/fast")
+	waitClients(t, b, 2)
+
+	// The slow client never reads, so its connection and queue fill up, while publishing continues.
+	blob := osc.AppendBlob(nil, make([]byte, 1<<20))
+	for i := 0; i < 64; i++ {
+		require.NoError(t, b.Publish(osc.AppendMessage(nil, "/slow", []byte("b"), blob)))
+	}
+
+	assert.Positive(t, b.Dropped())
+
+	require.NoError(t, b.Publish(message("/fast")))
+
+	_, payload := fast.read()
+	assert.Equal(t, message("/fast"), payload)
+}
+
+func TestNegativeConfig(t *testing.T) {
+	b := bridge.New(bridge.Config{QueueSize: -1, WriteTimeout: -1})
+	c := connect(t, b, "")
+	waitClients(t, b, 1)
+
+	require.NoError(t, b.Publish(message("/a")))
+
+	_, payload := c.read()
+	assert.Equal(t, message("/a"), payload)
+}
+
+func TestServe(t *testing.T) {
+	network := transport.NewNetwork(transport.Config{})
+
+	conn, err := network.Listen("bridge")
+	require.NoError(t, err)
+
+	sender, err := network.Listen("sender")
+	require.NoError(t, err)
+
+	defer sender.Close()
+
+	b := bridge.New(bridge.Config{})
+	c := connect(t, b, "")
+	waitClients(t, b, 1)
+
+	done := make(chan error)
+	go func() { done <- b.Serve(conn) }()
+
+	_, err = sender.WriteTo([]byte("invalid"), transport.Addr("bridge"))
+	require.NoError(t, err)
+	_, err = sender.WriteTo(message("/VMC/Ext/T"), transport.Addr("bridge"))
+	require.NoError(t, err)
+
+	_, payload := c.read()
+	assert.Equal(t, message("/VMC/Ext/T"), payload)
+
+	require.NoError(t, conn.Close())
+	assert.Error(t, <-done)
+}
+
+func TestHandshake(t *testing.T) {
+	b := bridge.New(bridge.Config{})
+
+	rec := httptest.NewRecorder()
+	b.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/?format=xml", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+
+	rec = httptest.NewRecorder()
+	b.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "xml")
+}
@@ -0,0 +1,185 @@
+package bridge
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Errors of the WebSocket protocol.
+var (
+	ErrHandshake      = errors.New("invalid websocket handshake")
+	ErrUnmaskedFrame  = errors.New("client frame is not masked")
+	ErrFrameTooLarge  = errors.New("client frame is too large")
+	ErrInvalidControl = errors.New("invalid control frame")
+	ErrReservedBits   = errors.New("reserved bits are set without an extension")
+	ErrUnknownOpcode  = errors.New("unknown opcode")
+	ErrFragmentation  = errors.New("invalid message fragmentation")
+	ErrInvalidUTF8    = errors.New("text message is not valid UTF-8")
+)
+
+// websocketGUID is appended to the client key, to calculate the accept key of the handshake.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket frame opcodes, as defined in RFC 6455.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// Close status codes, as defined in RFC 6455.
+const (
+	closeNormal      = 1000
+	closeProtocol    = 1002
+	closeUnsupported = 1003
+	closeInvalidData = 1007
+	closePolicy      = 1008
+	closeTooLarge    = 1009
+)
+
+// acceptKey checks the upgrade request, and returns the accept key for the response.
+func acceptKey(r *http.Request) (string, error) {
+	switch {
+	case r.Method != http.MethodGet:
+		return "", fmt.Errorf("%w: method %s", ErrHandshake, r.Method)
+	case !headerContains(r.Header, "Connection", "upgrade"):
+		return "", fmt.Errorf("%w: missing connection upgrade", ErrHandshake)
+	case !headerContains(r.Header, "Upgrade", "websocket"):
+		return "", fmt.Errorf("%w: missing websocket upgrade", ErrHandshake)
+	case r.Header.Get("Sec-WebSocket-Version") != "13":
+		return "", fmt.Errorf("%w: unsupported version %q",
+			ErrHandshake, r.Header.Get("Sec-WebSocket-Version"))
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != 16 {
+		return "", fmt.Errorf("%w: invalid key %q", ErrHandshake, key)
+	}
+
+	hash := sha1.Sum([]byte(key + websocketGUID))
+
+	return base64.StdEncoding.EncodeToString(hash[:]), nil
+}
+
+// headerContains tells whether any comma separated value of the header equals the token, ignoring
+// the case.
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// appendFrame appends a single, unfragmented and unmasked frame, as sent by servers.
+func appendFrame(buf []byte, opcode byte, payload []byte) []byte {
+	buf = append(buf, 0x80|opcode)
+
+	switch {
+	case len(payload) < 126:
+		buf = append(buf, byte(len(payload)))
+	case len(payload) <= 0xffff:
+		buf = append(buf, 126, byte(len(payload)>>8), byte(len(payload)))
+	default:
+		var size [8]byte
+
+		binary.BigEndian.PutUint64(size[:], uint64(len(payload)))
+		buf = append(append(buf, 127), size[:]...)
+	}
+
+	return append(buf, payload...)
+}
+
+// appendClose appends a close frame with the status code.
+func appendClose(buf []byte, code uint16) []byte {
+	return appendFrame(buf, opClose, []byte{byte(code >> 8), byte(code)})
+}
+
+// frame is a single frame, received from a client.
+type frame struct {
+	fin     bool
+	opcode  byte
+	payload []byte
+}
+
+// readFrame reads a single masked frame from a client. The payload is appended to buf, to reuse
+// its memory.
+func readFrame(r *bufio.Reader, buf []byte, limit int) (frame, error) {
+	var header [2]byte
+
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return frame{}, fmt.Errorf("failed reading frame header: %w", err)
+	}
+
+	f := frame{fin: header[0]&0x80 != 0, opcode: header[0] & 0x0f, payload: nil}
+
+	// No extensions are negotiated during the handshake, so the reserved bits must be zero.
+	if header[0]&0x70 != 0 {
+		return f, ErrReservedBits
+	}
+
+	if header[1]&0x80 == 0 {
+		return f, ErrUnmaskedFrame
+	}
+
+	size := uint64(header[1] & 0x7f)
+
+	switch size {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return f, fmt.Errorf("failed reading frame size: %w", err)
+		}
+
+		size = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return f, fmt.Errorf("failed reading frame size: %w", err)
+		}
+
+		size = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if f.opcode >= opClose && (!f.fin || size > 125) {
+		return f, ErrInvalidControl
+	}
+
+	if size > uint64(limit) {
+		return f, ErrFrameTooLarge
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(r, mask[:]); err != nil {
+		return f, fmt.Errorf("failed reading frame mask: %w", err)
+	}
+
+	start := len(buf)
+	buf = append(buf, make([]byte, size)...)
+
+	if _, err := io.ReadFull(r, buf[start:]); err != nil {
+		return f, fmt.Errorf("failed reading frame payload: %w", err)
+	}
+
+	for i := range buf[start:] {
+		buf[start+i] ^= mask[i%4]
+	}
+
+	f.payload = buf
+
+	return f, nil
+}
@@ -0,0 +1,47 @@
+// Command oscgen generates reflection-free implementations of osc.Marshaler and osc.Unmarshaler
+// for structs, following the same field mapping as osc.Marshal and osc.Unmarshal.
+//
+// It's meant to be invoked through go:generate, from within the package that declares the types:
+//
+//	//go:generate go run github.com/dnaka91/go-vmcparser/cmd/oscgen -type Foo,Bar
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dnaka91/go-vmcparser/internal/oscgen"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names (required)")
+	output := flag.String("output", "", "output file name (default <type>_osc.go)")
+	dir := flag.String("dir", ".", "directory of the package, that declares the types")
+
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	types := strings.Split(*typeNames, ",")
+
+	if *output == "" {
+		*output = strings.ToLower(types[0]) + "_osc.go"
+	}
+
+	src, err := oscgen.Generate(*dir, types)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "oscgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(filepath.Join(*dir, *output), src, 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, "oscgen: %v\n", err)
+		os.Exit(1)
+	}
+}
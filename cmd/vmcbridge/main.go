@@ -0,0 +1,77 @@
+// Command vmcbridge receives a VMC stream over UDP, and serves it to browsers over WebSocket.
+//
+//	go run github.com/dnaka91/go-vmcparser/cmd/vmcbridge -udp :39539 -http :8080 -format json
+//
+// Clients connect to ws://localhost:8080/ and may select messages with subscribe query parameters,
+// as described in the bridge package.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/bridge"
+	"github.com/dnaka91/go-vmcparser/transport"
+)
+
+func main() {
+	udpAddr := flag.String("udp", ":39539", "UDP address to receive VMC messages on")
+	httpAddr := flag.String("http", ":8080", "HTTP address to serve WebSocket clients on")
+	path := flag.String("path", "/", "HTTP path of the WebSocket endpoint")
+	format := flag.String("format", bridge.FormatOSC.String(), "default message format, osc or json")
+	queue := flag.Int("queue", bridge.DefaultQueueSize, "messages buffered per client")
+
+	flag.Parse()
+
+	config := bridge.Config{
+		Format:       bridge.FormatOSC,
+		QueueSize:    *queue,
+		WriteTimeout: 0,
+	}
+
+	switch *format {
+	case bridge.FormatOSC.String():
+	case bridge.FormatJSON.String():
+		config.Format = bridge.FormatJSON
+	default:
+		fmt.Fprintf(os.Stderr, "vmcbridge: unknown format %q\n", *format)
+		os.Exit(2)
+	}
+
+	if err := run(*udpAddr, *httpAddr, *path, config); err != nil {
+		fmt.Fprintf(os.Stderr, "vmcbridge: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(udpAddr, httpAddr, path string, config bridge.Config) error {
+	conn, err := transport.ListenUDP(udpAddr)
+	if err != nil {
+		return fmt.Errorf("failed opening socket: %w", err)
+	}
+	defer conn.Close()
+
+	b := bridge.New(config)
+
+	mux := http.NewServeMux()
+	mux.Handle(path, b)
+
+	var server http.Server
+
+	server.Addr = httpAddr
+	server.Handler = mux
+	server.ReadHeaderTimeout = 10 * time.Second
+
+	errs := make(chan error, 2)
+
+	go func() { errs <- b.Serve(conn) }()
+	go func() { errs <- server.ListenAndServe() }()
+
+	err = <-errs
+	server.Close()
+
+	return fmt.Errorf("bridge stopped: %w", err)
+}
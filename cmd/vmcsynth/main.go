@@ -0,0 +1,93 @@
+// Command vmcsynth sends a synthetic VMC motion stream to a UDP address, to test receivers without
+// a running VMC application.
+//
+//	go run github.com/dnaka91/go-vmcparser/cmd/vmcsynth -addr 127.0.0.1:39539 -phonemes "aiueo--"
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/synth"
+	"github.com/dnaka91/go-vmcparser/transport"
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:39539", "UDP address of the receiver")
+	rate := flag.Float64("rate", synth.DefaultRate, "frames per second")
+	phonemes := flag.String("phonemes", "a-i-u-e-o-", "looped vowel sequence for the lip sync")
+	seed := flag.Int64("seed", 0, "seed for the blink intervals")
+	duration := flag.Duration("duration", 0, "time to send for, or forever if zero")
+	bundles := flag.Bool("bundles", true, "send each frame as bundle, instead of individual messages")
+
+	flag.Parse()
+
+	if err := run(*addr, *duration, *bundles, synth.Config{
+		Rate:            *rate,
+		Motions:         synth.MotionAll,
+		BlinkInterval:   0,
+		Phonemes:        *phonemes,
+		PhonemeDuration: 0,
+		Seed:            *seed,
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "vmcsynth: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(addr string, duration time.Duration, bundles bool, config synth.Config) error {
+	target, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %w", addr, err)
+	}
+
+	// The socket isn't connected, so that sending continues while the receiver isn't running yet.
+	conn, err := transport.ListenUDP(":0")
+	if err != nil {
+		return fmt.Errorf("failed opening socket: %w", err)
+	}
+	defer conn.Close()
+
+	generator := synth.New(config)
+	ticker := time.NewTicker(generator.Interval())
+
+	defer ticker.Stop()
+
+	var buf []byte
+
+	start := time.Now()
+
+	for now := range ticker.C {
+		elapsed := now.Sub(start)
+		if duration > 0 && elapsed > duration {
+			return nil
+		}
+
+		if bundles {
+			if _, err := conn.WriteTo(generator.Bundle(elapsed), target); err != nil {
+				return fmt.Errorf("failed sending bundle: %w", err)
+			}
+
+			continue
+		}
+
+		generator.Messages(elapsed, func(msg vmc.Encodable) {
+			if err != nil {
+				return
+			}
+
+			buf = msg.AppendMessage(buf[:0])
+			_, err = conn.WriteTo(buf, target)
+		})
+
+		if err != nil {
+			return fmt.Errorf("failed sending message: %w", err)
+		}
+	}
+
+	return nil
+}
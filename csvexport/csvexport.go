@@ -0,0 +1,246 @@
+// Package csvexport converts a stream of VMC messages into CSV tables, with one row per frame.
+//
+// Each row contains the frame index, the relative time, the root pose, the position and rotation
+// of every bone and the value of every blend shape. Bones and blend shapes are discovered from the
+// stream itself, so custom names are supported as well.
+//
+// The resulting columns are named as follows:
+//
+//	frame, time                  Frame index (starting at 0) and relative time.
+//	root.px ... root.qw          Root position and quaternion.
+//	bone.<name>.px ... .qw       Position and quaternion of a single bone.
+//	blend.<name>                 Value of a single blend shape.
+package csvexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+// DefaultDiscoveryFrames is the amount of frames, that are buffered for column discovery if
+// Writer.DiscoveryFrames is zero. It's about 5 seconds of a stream with 60 frames per second.
+const DefaultDiscoveryFrames = 300
+
+// Writer writes VMC messages as CSV rows into an underlying writer.
+//
+// As the header must be written before any row, the first frames are buffered to discover the
+// names of bones and blend shapes. Names that show up for the first time after the header was
+// written are not exported, but reported by Dropped.
+type Writer struct {
+	// DiscoveryFrames is the amount of frames buffered for column discovery, before the header
+	// and the first rows are written. If zero, DefaultDiscoveryFrames is used. If negative, all
+	// frames are buffered until Flush is called, which is best suited for exporting complete
+	// captures, but unsuited for live streams as memory grows without bounds.
+	DiscoveryFrames int
+
+	csv     *csv.Writer
+	builder vmc.FrameBuilder
+	index   int
+
+	bones       []string
+	blendShapes []string
+	seenBones   map[string]struct{}
+	seenBlends  map[string]struct{}
+	dropped     []string
+
+	pending       []vmc.Frame
+	headerWritten bool
+	row           []string
+}
+
+// NewWriter creates a new writer, that writes into w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		DiscoveryFrames: 0,
+		csv:             csv.NewWriter(w),
+		builder:         vmc.FrameBuilder{},
+		index:           0,
+		bones:           nil,
+		blendShapes:     nil,
+		seenBones:       make(map[string]struct{}),
+		seenBlends:      make(map[string]struct{}),
+		dropped:         nil,
+		pending:         nil,
+		headerWritten:   false,
+		row:             nil,
+	}
+}
+
+// Write adds a single message to the current frame. Once the frame is completed by a
+// BlendShapeProxyApply message, it's written as row (or buffered, if the columns are still being
+// discovered).
+func (w *Writer) Write(msg vmc.Message) error {
+	w.discover(msg)
+
+	if w.builder.Add(msg) {
+		return w.EndFrame()
+	}
+
+	return nil
+}
+
+// EndFrame completes the current frame, without waiting for a BlendShapeProxyApply message. This
+// is useful for streams that don't contain any blend shapes.
+func (w *Writer) EndFrame() error {
+	if w.headerWritten {
+		return w.writeFrame(w.builder.Frame())
+	}
+
+	w.pending = append(w.pending, w.builder.Frame().Clone())
+
+	limit := w.DiscoveryFrames
+	if limit == 0 {
+		limit = DefaultDiscoveryFrames
+	}
+
+	if limit > 0 && len(w.pending) >= limit {
+		return w.writePending()
+	}
+
+	return nil
+}
+
+// Flush writes any buffered frames, and flushes the underlying writer. The header is written as
+// well, in case it wasn't yet, which ends the column discovery.
+func (w *Writer) Flush() error {
+	if !w.headerWritten {
+		if err := w.writePending(); err != nil {
+			return err
+		}
+	}
+
+	w.csv.Flush()
+
+	if err := w.csv.Error(); err != nil {
+		return fmt.Errorf("failed flushing CSV data: %w", err)
+	}
+
+	return nil
+}
+
+// Dropped returns the columns of bones and blend shapes, that were discovered only after the header
+// was written, and therefore are missing from the export. They're named like the header columns,
+// without the pose suffix, for example "bone.LeftEye" or "blend.Joy".
+func (w *Writer) Dropped() []string {
+	return w.dropped
+}
+
+func (w *Writer) discover(msg vmc.Message) {
+	switch m := msg.(type) {
+	case *vmc.BoneTransform:
+		if _, ok := w.seenBones[string(m.Name)]; !ok {
+			w.seenBones[string(m.Name)] = struct{}{}
+			w.addColumn(&w.bones, "bone.", string(m.Name))
+		}
+	case *vmc.BlendShapeProxyValue:
+		if _, ok := w.seenBlends[string(m.Name)]; !ok {
+			w.seenBlends[string(m.Name)] = struct{}{}
+			w.addColumn(&w.blendShapes, "blend.", string(m.Name))
+		}
+	}
+}
+
+func (w *Writer) addColumn(names *[]string, prefix, name string) {
+	if w.headerWritten {
+		w.dropped = append(w.dropped, prefix+name)
+	} else {
+		*names = append(*names, name)
+	}
+}
+
+func (w *Writer) writePending() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	for i := range w.pending {
+		if err := w.writeFrame(&w.pending[i]); err != nil {
+			return err
+		}
+	}
+
+	w.pending = nil
+
+	return nil
+}
+
+func (w *Writer) writeHeader() error {
+	header := make([]string, 0, 2+7*(1+len(w.bones))+len(w.blendShapes))
+	header = append(header, "frame", "time")
+	header = appendPoseHeader(header, "root")
+
+	for _, name := range w.bones {
+		header = appendPoseHeader(header, "bone."+name)
+	}
+
+	for _, name := range w.blendShapes {
+		header = append(header, "blend."+name)
+	}
+
+	if err := w.csv.Write(header); err != nil {
+		return fmt.Errorf("failed writing CSV header: %w", err)
+	}
+
+	w.headerWritten = true
+	w.row = make([]string, 0, len(header))
+
+	return nil
+}
+
+func (w *Writer) writeFrame(frame *vmc.Frame) error {
+	row := w.row[:0]
+	row = append(row, strconv.Itoa(w.index), formatFloat(frame.Time))
+	row = appendPose(row, frame.Root)
+
+	for _, name := range w.bones {
+		if pose, ok := frame.Bones[name]; ok {
+			row = appendPose(row, pose)
+		} else {
+			row = append(row, "", "", "", "", "", "", "")
+		}
+	}
+
+	for _, name := range w.blendShapes {
+		if value, ok := frame.BlendShapes[name]; ok {
+			row = append(row, formatFloat(value))
+		} else {
+			row = append(row, "")
+		}
+	}
+
+	w.row = row
+	w.index++
+
+	if err := w.csv.Write(row); err != nil {
+		return fmt.Errorf("failed writing CSV row: %w", err)
+	}
+
+	return nil
+}
+
+func appendPoseHeader(header []string, prefix string) []string {
+	return append(header,
+		prefix+".px", prefix+".py", prefix+".pz",
+		prefix+".qx", prefix+".qy", prefix+".qz", prefix+".qw",
+	)
+}
+
+func appendPose(row []string, pose vmc.Pose) []string {
+	return append(row,
+		formatFloat(pose.Position.X),
+		formatFloat(pose.Position.Y),
+		formatFloat(pose.Position.Z),
+		formatFloat(pose.Quaternion.X),
+		formatFloat(pose.Quaternion.Y),
+		formatFloat(pose.Quaternion.Z),
+		formatFloat(pose.Quaternion.W),
+	)
+}
+
+func formatFloat(value float32) string {
+	return strconv.FormatFloat(float64(value), 'g', -1, 32)
+}
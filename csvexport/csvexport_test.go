@@ -0,0 +1,79 @@
+package csvexport_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/csvexport"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFrame(t *testing.T, w *csvexport.Writer, time float32, messages ...vmc.Message) {
+	t.Helper()
+
+	require.NoError(t, w.Write(&vmc.RelativeTime{Time: time}))
+
+	for _, msg := range messages {
+		require.NoError(t, w.Write(msg))
+	}
+
+	require.NoError(t, w.Write(&vmc.BlendShapeProxyApply{}))
+}
+
+func TestWriterFullCapture(t *testing.T) {
+	var buf bytes.Buffer
+	w := csvexport.NewWriter(&buf)
+	w.DiscoveryFrames = -1
+
+	writeFrame(t, w, 0.5,
+		&vmc.RootTransform{Name: []byte("root"), Position: vmc.Vec3{X: 1}, Quaternion: vmc.Vec4{W: 1}},
+		&vmc.BoneTransform{Name: []byte("Hips"), Position: vmc.Vec3{Y: 1}, Quaternion: vmc.Vec4{W: 1}},
+		&vmc.BlendShapeProxyValue{Name: []byte("A"), Value: 0.25},
+	)
+	writeFrame(t, w, 1,
+		&vmc.BoneTransform{Name: []byte("Custom"), Position: vmc.Vec3{Z: 2}, Quaternion: vmc.Vec4{X: 1}},
+		&vmc.BlendShapeProxyValue{Name: []byte("Joy"), Value: 1},
+	)
+	require.NoError(t, w.Flush())
+
+	want := "frame,time,root.px,root.py,root.pz,root.qx,root.qy,root.qz,root.qw," +
+		"bone.Hips.px,bone.Hips.py,bone.Hips.pz,bone.Hips.qx,bone.Hips.qy,bone.Hips.qz,bone.Hips.qw," +
+		"bone.Custom.px,bone.Custom.py,bone.Custom.pz,bone.Custom.qx,bone.Custom.qy,bone.Custom.qz,bone.Custom.qw," +
+		"blend.A,blend.Joy\n" +
+		"0,0.5,1,0,0,0,0,0,1,0,1,0,0,0,0,1,,,,,,,,0.25,\n" +
+		"1,1,1,0,0,0,0,0,1,0,1,0,0,0,0,1,0,0,2,1,0,0,0,0.25,1\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestWriterDiscoveryFrames(t *testing.T) {
+	var buf bytes.Buffer
+	w := csvexport.NewWriter(&buf)
+	w.DiscoveryFrames = 1
+
+	writeFrame(t, w, 0, &vmc.BlendShapeProxyValue{Name: []byte("A"), Value: 0.5})
+	writeFrame(t, w, 1, &vmc.BlendShapeProxyValue{Name: []byte("Late"), Value: 1})
+	require.NoError(t, w.Flush())
+
+	want := "frame,time,root.px,root.py,root.pz,root.qx,root.qy,root.qz,root.qw,blend.A\n" +
+		"0,0,0,0,0,0,0,0,0,0.5\n" +
+		"1,1,0,0,0,0,0,0,0,0.5\n"
+	assert.Equal(t, want, buf.String())
+	assert.Equal(t, []string{"blend.Late"}, w.Dropped())
+}
+
+func TestWriterDefaultDiscoveryFrames(t *testing.T) {
+	var buf bytes.Buffer
+	w := csvexport.NewWriter(&buf)
+
+	for i := 0; i < csvexport.DefaultDiscoveryFrames; i++ {
+		writeFrame(t, w, float32(i))
+	}
+
+	// The header was written after the discovery window, so the bone doesn't make it into the export.
+	writeFrame(t, w, 0, &vmc.BoneTransform{Name: []byte("Late"), Quaternion: vmc.Vec4{W: 1}})
+	require.NoError(t, w.Flush())
+	assert.NotContains(t, buf.String(), "Late")
+	assert.Equal(t, []string{"bone.Late"}, w.Dropped())
+}
@@ -0,0 +1,251 @@
+// Package filter smooths jittery tracking data of VMC message streams.
+//
+// A Filter is used as a stage between parsing and re-encoding of messages. It modifies the poses of
+// root, bone and device transforms, as well as blend shape values, in place:
+//
+//	msg, err := decoder.Decode(packet)
+//	// handle error
+//	f.Process(msg, time.Now())
+//	if encodable, ok := msg.(vmc.Encodable); ok {
+//		out = encodable.AppendMessage(out[:0])
+//	}
+//
+// Each transform and blend shape is filtered individually, keyed by its name, and the smoothing can
+// be configured per bone.
+package filter
+
+import (
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+// Transform configures the smoothing of a single transform.
+type Transform struct {
+	Position Smoothing // Position is the smoothing of the position.
+	Rotation Smoothing // Rotation is the smoothing of the rotation quaternion.
+}
+
+// Config selects the smoothing for all the values of a message stream.
+type Config struct {
+	// Default is the smoothing of all transforms, that have no specific configuration.
+	Default Transform
+	// Root overrides the smoothing of the root transform.
+	Root vmc.Optional[Transform]
+	// Devices overrides the smoothing of all device transforms.
+	Devices vmc.Optional[Transform]
+	// Bones overrides the smoothing of individual bones, keyed by bone name.
+	Bones map[string]Transform
+	// BlendShapes is the smoothing of all blend shape values.
+	BlendShapes Smoothing
+}
+
+// Filter smooths the values of a message stream. Each value keeps its own state, so a filter must
+// only be used for a single stream. It must not be used concurrently.
+type Filter struct {
+	config      Config
+	root        *transform
+	bones       map[string]*transform
+	devices     map[string]*transform
+	localDevice map[string]*transform
+	blendShapes map[string]*channel
+}
+
+// New creates a new filter with the given configuration.
+func New(config Config) *Filter {
+	return &Filter{
+		config:      config,
+		root:        nil,
+		bones:       make(map[string]*transform),
+		devices:     make(map[string]*transform),
+		localDevice: make(map[string]*transform),
+		blendShapes: make(map[string]*channel),
+	}
+}
+
+// Process smooths the message in place, considering it was received at the given time. Messages
+// without any tracking data are left untouched.
+func (f *Filter) Process(msg vmc.Message, at time.Time) {
+	switch m := msg.(type) {
+	case *vmc.RootTransform:
+		if f.root == nil {
+			f.root = newTransform(f.config.Root.Or(f.config.Default))
+		}
+
+		m.Position, m.Quaternion = f.root.filter(at, m.Position, m.Quaternion)
+	case *vmc.BoneTransform:
+		state, ok := f.bones[string(m.Name)]
+		if !ok {
+			config, found := f.config.Bones[string(m.Name)]
+			if !found {
+				config = f.config.Default
+			}
+
+			state = newTransform(config)
+			f.bones[string(m.Name)] = state
+		}
+
+		m.Position, m.Quaternion = state.filter(at, m.Position, m.Quaternion)
+	case *vmc.DeviceTransform:
+		devices := f.devices
+		if m.Local {
+			devices = f.localDevice
+		}
+
+		state, ok := devices[string(m.Serial)]
+		if !ok {
+			state = newTransform(f.config.Devices.Or(f.config.Default))
+			devices[string(m.Serial)] = state
+		}
+
+		m.Position, m.Quaternion = state.filter(at, m.Position, m.Quaternion)
+	case *vmc.BlendShapeProxyValue:
+		state, ok := f.blendShapes[string(m.Name)]
+		if !ok {
+			state = new(channel)
+			f.blendShapes[string(m.Name)] = state
+		}
+
+		values := [1]float64{float64(m.Value)}
+		state.filter(&f.config.BlendShapes, at, values[:])
+		m.Value = float32(values[0])
+	}
+}
+
+// Reset clears the state of all values, so the next values are passed through unchanged and the
+// smoothing starts anew. This is useful when the sender changed, or the stream was interrupted for
+// a longer time.
+func (f *Filter) Reset() {
+	f.root = nil
+
+	for name := range f.bones {
+		delete(f.bones, name)
+	}
+
+	for serial := range f.devices {
+		delete(f.devices, serial)
+	}
+
+	for serial := range f.localDevice {
+		delete(f.localDevice, serial)
+	}
+
+	for name := range f.blendShapes {
+		delete(f.blendShapes, name)
+	}
+}
+
+// transform is the state of a single transform.
+type transform struct {
+	config   Transform
+	position channel
+	rotation channel
+}
+
+func newTransform(config Transform) *transform {
+	return &transform{config: config, position: channel{}, rotation: channel{}}
+}
+
+func (t *transform) filter(
+	at time.Time, position vmc.Vec3, rotation vmc.Vec4,
+) (vmc.Vec3, vmc.Vec4) {
+	values := [3]float64{float64(position.X), float64(position.Y), float64(position.Z)}
+	t.position.filter(&t.config.Position, at, values[:])
+
+	position = vmc.Vec3{X: float32(values[0]), Y: float32(values[1]), Z: float32(values[2])}
+	rotation = t.rotation.filterRotation(&t.config.Rotation, at, rotation)
+
+	return position, rotation
+}
+
+// channel is the state of a value with up to four components.
+type channel struct {
+	initialized bool
+	last        time.Time
+	components  [4]oneEuro
+}
+
+// filter smooths the values in place.
+func (c *channel) filter(s *Smoothing, at time.Time, values []float64) {
+	if !c.initialized {
+		for i, value := range values {
+			c.components[i].reset(value)
+		}
+
+		c.initialized = true
+		c.last = at
+
+		return
+	}
+
+	elapsed := at.Sub(c.last).Seconds()
+	c.last = at
+
+	for i, value := range values {
+		component := &c.components[i]
+
+		switch s.Method {
+		case MethodNone:
+			component.reset(value)
+		case MethodOneEuro:
+			// Without any elapsed time, the speed can't be estimated, so the previous value is kept.
+			if elapsed > 0 {
+				component.filter(s, value, elapsed)
+			}
+
+			values[i] = component.value
+		case MethodAverage:
+			component.value += s.Alpha * (value - component.value)
+			values[i] = component.value
+		}
+	}
+}
+
+// filterRotation smooths a rotation quaternion. Averages use spherical interpolation, while the 1€
+// filter smooths each component and normalizes the result.
+func (c *channel) filterRotation(s *Smoothing, at time.Time, rotation vmc.Vec4) vmc.Vec4 {
+	previous := vmc.Vec4{
+		X: float32(c.components[0].value),
+		Y: float32(c.components[1].value),
+		Z: float32(c.components[2].value),
+		W: float32(c.components[3].value),
+	}
+
+	if s.Method == MethodNone {
+		return rotation
+	}
+
+	if c.initialized && s.Method == MethodAverage {
+		rotation = previous.Slerp(rotation, float32(s.Alpha))
+		c.last = at
+
+		for i, value := range [...]float32{rotation.X, rotation.Y, rotation.Z, rotation.W} {
+			c.components[i].value = float64(value)
+		}
+
+		return rotation
+	}
+
+	// q and -q describe the same rotation. Flipping the new value into the hemisphere of the
+	// previous one avoids smoothing along the long way around.
+	if c.initialized && previous.Dot(rotation) < 0 {
+		rotation = rotation.Scale(-1)
+	}
+
+	initialized := c.initialized
+	values := [4]float64{
+		float64(rotation.X), float64(rotation.Y), float64(rotation.Z), float64(rotation.W),
+	}
+	c.filter(s, at, values[:])
+
+	if !initialized {
+		return rotation
+	}
+
+	return vmc.Vec4{
+		X: float32(values[0]),
+		Y: float32(values[1]),
+		Z: float32(values[2]),
+		W: float32(values[3]),
+	}.Normalize()
+}
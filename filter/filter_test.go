@@ -0,0 +1,171 @@
+package filter_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/filter"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+)
+
+const frame = time.Second / 60
+
+func bone(name string, x float32, rotation vmc.Vec4) *vmc.BoneTransform {
+	return &vmc.BoneTransform{Name: []byte(name), Position: vmc.Vec3{X: x, Y: 0, Z: 0}, Quaternion: rotation}
+}
+
+func identity() vmc.Vec4 {
+	return vmc.Vec4{X: 0, Y: 0, Z: 0, W: 1}
+}
+
+// rotationY creates a quaternion for a rotation around the Y axis.
+func rotationY(degrees float64) vmc.Vec4 {
+	half := degrees * math.Pi / 360
+
+	return vmc.Vec4{X: 0, Y: float32(math.Sin(half)), Z: 0, W: float32(math.Cos(half))}
+}
+
+func TestNone(t *testing.T) {
+	f := filter.New(filter.Config{})
+	start := time.Now()
+
+	for i, x := range []float32{1, 5, -3} {
+		msg := bone("Hips", x, rotationY(float64(x)*10))
+		f.Process(msg, start.Add(time.Duration(i)*frame))
+		assert.Equal(t, bone("Hips", x, rotationY(float64(x)*10)), msg)
+	}
+}
+
+func TestAverage(t *testing.T) {
+	f := filter.New(filter.Config{
+		Default:     filter.Transform{Position: filter.Average(0.5), Rotation: filter.Average(0.5)},
+		BlendShapes: filter.Average(0.25),
+	})
+	start := time.Now()
+
+	msg := bone("Hips", 0, identity())
+	f.Process(msg, start)
+	assert.Equal(t, bone("Hips", 0, identity()), msg)
+
+	msg = bone("Hips", 10, rotationY(90))
+	f.Process(msg, start.Add(frame))
+	assert.Equal(t, float32(5), msg.Position.X)
+	assert.InDelta(t, rotationY(45).Y, msg.Quaternion.Y, 1e-6)
+	assert.InDelta(t, rotationY(45).W, msg.Quaternion.W, 1e-6)
+
+	blend := &vmc.BlendShapeProxyValue{Name: []byte("A"), Value: 0}
+	f.Process(blend, start)
+
+	blend.Value = 1
+	f.Process(blend, start.Add(frame))
+	assert.Equal(t, float32(0.25), blend.Value)
+}
+
+func TestOneEuroReducesJitter(t *testing.T) {
+	f := filter.New(filter.Config{
+		Default: filter.Transform{Position: filter.OneEuro(1, 0.1), Rotation: filter.OneEuro(1, 0.1)},
+	})
+	rng := rand.New(rand.NewSource(1))
+	start := time.Now()
+
+	var rawError, filteredError float64
+
+	for i := 0; i < 300; i++ {
+		noise := float32(rng.NormFloat64() * 0.01)
+		msg := bone("Hips", 1+noise, rotationY(float64(noise)*100))
+		f.Process(msg, start.Add(time.Duration(i)*frame))
+
+		if i >= 60 {
+			rawError += math.Abs(float64(noise))
+			filteredError += math.Abs(float64(msg.Position.X - 1))
+		}
+	}
+
+	assert.Less(t, filteredError, rawError/2)
+}
+
+func TestOneEuroFollowsMovement(t *testing.T) {
+	f := filter.New(filter.Config{
+		Default: filter.Transform{Position: filter.OneEuro(1, 1), Rotation: filter.OneEuro(1, 1)},
+	})
+	start := time.Now()
+
+	f.Process(bone("Hips", 0, identity()), start)
+
+	var msg *vmc.BoneTransform
+
+	for i := 1; i <= 120; i++ {
+		msg = bone("Hips", 2, rotationY(90))
+		f.Process(msg, start.Add(time.Duration(i)*frame))
+	}
+
+	assert.InDelta(t, 2, msg.Position.X, 0.01)
+	assert.InDelta(t, rotationY(90).Y, msg.Quaternion.Y, 0.01)
+	assert.InDelta(t, 1, msg.Quaternion.Length(), 1e-6)
+}
+
+func TestRotationHemisphere(t *testing.T) {
+	f := filter.New(filter.Config{
+		Default: filter.Transform{Position: filter.Smoothing{}, Rotation: filter.OneEuro(1, 0)},
+	})
+	start := time.Now()
+
+	f.Process(bone("Hips", 0, rotationY(30)), start)
+
+	// The negated quaternion is the same rotation, and must not be pulled towards the identity.
+	msg := bone("Hips", 0, rotationY(30).Scale(-1))
+	f.Process(msg, start.Add(frame))
+
+	assert.InDelta(t, rotationY(30).Y, msg.Quaternion.Y, 1e-6)
+	assert.InDelta(t, rotationY(30).W, msg.Quaternion.W, 1e-6)
+}
+
+func TestPerBoneConfig(t *testing.T) {
+	f := filter.New(filter.Config{
+		Default: filter.Transform{Position: filter.Average(0.5), Rotation: filter.Average(0.5)},
+		Root:    vmc.Some(filter.Transform{}),
+		Bones:   map[string]filter.Transform{"Head": {}},
+	})
+	start := time.Now()
+
+	for _, name := range []string{"Head", "Hips"} {
+		f.Process(bone(name, 0, identity()), start)
+	}
+
+	head := bone("Head", 10, identity())
+	f.Process(head, start.Add(frame))
+	assert.Equal(t, float32(10), head.Position.X)
+
+	hips := bone("Hips", 10, identity())
+	f.Process(hips, start.Add(frame))
+	assert.Equal(t, float32(5), hips.Position.X)
+
+	root := &vmc.RootTransform{Name: []byte("root"), Quaternion: identity()}
+	f.Process(root, start)
+
+	root.Position.X = 10
+	f.Process(root, start.Add(frame))
+	assert.Equal(t, float32(10), root.Position.X)
+}
+
+func TestReset(t *testing.T) {
+	f := filter.New(filter.Config{
+		Default: filter.Transform{Position: filter.Average(0.5), Rotation: filter.Average(0.5)},
+	})
+	start := time.Now()
+
+	f.Process(bone("Hips", 0, identity()), start)
+	f.Reset()
+
+	msg := bone("Hips", 10, identity())
+	f.Process(msg, start.Add(frame))
+	assert.Equal(t, float32(10), msg.Position.X)
+}
+
+func TestMethodString(t *testing.T) {
+	assert.Equal(t, "OneEuro", filter.MethodOneEuro.String())
+	assert.Equal(t, "Unknown(9)", filter.Method(9).String())
+}
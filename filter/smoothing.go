@@ -0,0 +1,100 @@
+package filter
+
+import (
+	"fmt"
+	"math"
+)
+
+// Method is the algorithm, that smooths a stream of values.
+type Method uint8
+
+// Possible smoothing methods.
+const (
+	// MethodNone passes all values through unchanged.
+	MethodNone Method = iota
+	// MethodOneEuro is the 1€ filter, an adaptive low-pass filter that smooths heavily at low
+	// speeds to remove jitter, and less at high speeds to keep the lag low.
+	MethodOneEuro
+	// MethodAverage is an exponential moving average. Positions and blend shapes are averaged
+	// linearly, while rotations are averaged with spherical interpolation.
+	MethodAverage
+)
+
+func (m Method) String() string {
+	switch m {
+	case MethodNone:
+		return "None"
+	case MethodOneEuro:
+		return "OneEuro"
+	case MethodAverage:
+		return "Average"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(m))
+	}
+}
+
+// Smoothing configures the smoothing of a single kind of value.
+type Smoothing struct {
+	Method Method // Method is the smoothing algorithm.
+	// Alpha is the weight of each new value for MethodAverage, between 0 and 1. Lower values
+	// smooth stronger.
+	Alpha float64
+	// MinCutoff is the minimum cutoff frequency in Hz for MethodOneEuro. Lower values remove more
+	// jitter, but add more lag at low speeds.
+	MinCutoff float64
+	// Beta is the speed coefficient for MethodOneEuro. Higher values reduce the lag at high speeds.
+	Beta float64
+	// DerivativeCutoff is the cutoff frequency in Hz, for the speed estimation of MethodOneEuro.
+	DerivativeCutoff float64
+}
+
+// OneEuro creates the configuration for a 1€ filter, with the common derivative cutoff of 1 Hz.
+func OneEuro(minCutoff, beta float64) Smoothing {
+	return Smoothing{
+		Method:           MethodOneEuro,
+		Alpha:            0,
+		MinCutoff:        minCutoff,
+		Beta:             beta,
+		DerivativeCutoff: 1,
+	}
+}
+
+// Average creates the configuration for an exponential moving average.
+func Average(alpha float64) Smoothing {
+	return Smoothing{
+		Method:           MethodAverage,
+		Alpha:            alpha,
+		MinCutoff:        0,
+		Beta:             0,
+		DerivativeCutoff: 0,
+	}
+}
+
+// oneEuro is the state of a 1€ filter for a single value.
+type oneEuro struct {
+	value      float64
+	derivative float64
+}
+
+// smoothingFactor calculates the weight of a new value for a low-pass filter with the given cutoff
+// frequency, and the time since the last value.
+func smoothingFactor(cutoff, elapsed float64) float64 {
+	tau := 1 / (2 * math.Pi * cutoff)
+
+	return 1 / (1 + tau/elapsed)
+}
+
+func (f *oneEuro) reset(value float64) {
+	f.value = value
+	f.derivative = 0
+}
+
+func (f *oneEuro) filter(s *Smoothing, value, elapsed float64) float64 {
+	derivative := (value - f.value) / elapsed
+	f.derivative += smoothingFactor(s.DerivativeCutoff, elapsed) * (derivative - f.derivative)
+
+	cutoff := s.MinCutoff + s.Beta*math.Abs(f.derivative)
+	f.value += smoothingFactor(cutoff, elapsed) * (value - f.value)
+
+	return f.value
+}
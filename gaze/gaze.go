@@ -0,0 +1,185 @@
+// Package gaze converts between eye bone rotations, gaze angles and look-at targets.
+//
+// Eye tracking arrives either as rotations of the LeftEye and RightEye bones, or as a look-at
+// target with the EyeTarget message. Both are expressed as Gaze angles here, which are easier to
+// limit and to reason about than quaternions.
+//
+// All calculations use Unity's coordinate system, where X points right, Y up and Z forward. Eye
+// bones are expected to have no rotation in their rest pose, as it's the case for normalized VRM
+// models.
+package gaze
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+// Default limits of the gaze angles in degrees, that are used for zero values in Limits.
+const (
+	DefaultYawLimit   float32 = 15
+	DefaultPitchLimit float32 = 12
+)
+
+// Gaze is the viewing direction of an eye, relative to the head.
+type Gaze struct {
+	Yaw   float32 // Yaw is the horizontal angle in degrees. Positive values look right.
+	Pitch float32 // Pitch is the vertical angle in degrees. Positive values look up.
+}
+
+// FromRotation calculates the gaze of an eye bone rotation. Any roll around the viewing direction
+// is lost.
+func FromRotation(rotation vmc.Vec4) Gaze {
+	return direction(rotation.Rotate(vmc.Vec3{X: 0, Y: 0, Z: 1}))
+}
+
+// Rotation returns the eye bone rotation, that looks into the gaze direction.
+func (g Gaze) Rotation() vmc.Vec4 {
+	yaw := vmc.AxisAngle(vmc.Vec3{X: 0, Y: 1, Z: 0}, g.Yaw)
+	// Rotations around the X axis turn the forward direction down, so the pitch is inverted.
+	pitch := vmc.AxisAngle(vmc.Vec3{X: 1, Y: 0, Z: 0}, -g.Pitch)
+
+	return yaw.Mul(pitch)
+}
+
+// direction calculates the gaze, that looks along the vector. The zero vector looks straight
+// ahead.
+func direction(v vmc.Vec3) Gaze {
+	if v == (vmc.Vec3{X: 0, Y: 0, Z: 0}) {
+		return Gaze{Yaw: 0, Pitch: 0}
+	}
+
+	horizontal := math.Hypot(float64(v.X), float64(v.Z))
+
+	return Gaze{
+		Yaw:   degrees(math.Atan2(float64(v.X), float64(v.Z))),
+		Pitch: degrees(math.Atan2(float64(v.Y), horizontal)),
+	}
+}
+
+func degrees(radians float64) float32 {
+	return float32(radians * 180 / math.Pi)
+}
+
+// Limits are the maximum gaze angles in degrees, in each direction. If zero, DefaultYawLimit is
+// used for Left and Right, and DefaultPitchLimit for Up and Down.
+type Limits struct {
+	Left  float32
+	Right float32
+	Up    float32
+	Down  float32
+}
+
+// Clamp restricts the gaze to the limits.
+func (l Limits) Clamp(g Gaze) Gaze {
+	return Gaze{
+		Yaw:   clamp(g.Yaw, -orDefault(l.Left, DefaultYawLimit), orDefault(l.Right, DefaultYawLimit)),
+		Pitch: clamp(g.Pitch, -orDefault(l.Down, DefaultPitchLimit), orDefault(l.Up, DefaultPitchLimit)),
+	}
+}
+
+func orDefault(value, fallback float32) float32 {
+	if value == 0 {
+		return fallback
+	}
+
+	return value
+}
+
+func clamp(value, lower, upper float32) float32 {
+	switch {
+	case value < lower:
+		return lower
+	case value > upper:
+		return upper
+	default:
+		return value
+	}
+}
+
+// Eye is one of the two eye bones.
+type Eye uint8
+
+// Possible values for the eye.
+const (
+	EyeLeft Eye = iota
+	EyeRight
+)
+
+var _ fmt.Stringer = (*Eye)(nil)
+
+// String returns the bone name of the eye, as defined by Unity's HumanBodyBones.
+func (e Eye) String() string {
+	switch e {
+	case EyeLeft:
+		return "LeftEye"
+	case EyeRight:
+		return "RightEye"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(e))
+	}
+}
+
+// ParseEye finds the eye with the given bone name.
+func ParseEye(name []byte) (Eye, bool) {
+	switch string(name) {
+	case "LeftEye":
+		return EyeLeft, true
+	case "RightEye":
+		return EyeRight, true
+	default:
+		return 0, false
+	}
+}
+
+// Eyes are the positions of both eyes, relative to the head bone. The zero value places both eyes
+// at the origin of the head, which is close enough for distant targets.
+type Eyes struct {
+	Left  vmc.Vec3
+	Right vmc.Vec3
+}
+
+// LookAt calculates the gaze of both eyes, so they look at the target. The head pose and the
+// target must be given in the same coordinate space, like world or avatar root space.
+func (e Eyes) LookAt(head vmc.Pose, target vmc.Vec3) (left, right Gaze) {
+	local := head.Quaternion.Conjugate().Rotate(target.Sub(head.Position))
+
+	return direction(local.Sub(e.Left)), direction(local.Sub(e.Right))
+}
+
+// Target calculates the gaze of both eyes for an EyeTarget message, which is given in world space.
+// It returns false if the target is disabled.
+func (e Eyes) Target(head vmc.Pose, msg *vmc.EyeTarget) (left, right Gaze, ok bool) {
+	if !msg.Enable {
+		return Gaze{Yaw: 0, Pitch: 0}, Gaze{Yaw: 0, Pitch: 0}, false
+	}
+
+	left, right = e.LookAt(head, msg.Position)
+
+	return left, right, true
+}
+
+// Stage clamps the rotations of eye bones in place, to prevent unnatural gazes from noisy eye
+// tracking. The zero value uses the default limits.
+type Stage struct {
+	Limits Limits // Limits are the maximum gaze angles.
+}
+
+// Process clamps the message, if it's a LeftEye or RightEye bone transform. Rotations within the
+// limits are left untouched, while the others lose any roll when clamped.
+func (s *Stage) Process(msg vmc.Message) {
+	bone, ok := msg.(*vmc.BoneTransform)
+	if !ok {
+		return
+	}
+
+	if _, ok := ParseEye(bone.Name); !ok {
+		return
+	}
+
+	gaze := FromRotation(bone.Quaternion)
+	if clamped := s.Limits.Clamp(gaze); clamped != gaze {
+		bone.Quaternion = clamped.Rotation()
+	}
+}
@@ -0,0 +1,96 @@
+package gaze_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/gaze"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertGaze(t *testing.T, want, got gaze.Gaze) {
+	t.Helper()
+
+	assert.InDelta(t, want.Yaw, got.Yaw, 1e-3, "yaw")
+	assert.InDelta(t, want.Pitch, got.Pitch, 1e-3, "pitch")
+}
+
+func TestRotation(t *testing.T) {
+	tests := []gaze.Gaze{
+		{Yaw: 0, Pitch: 0},
+		{Yaw: 10, Pitch: 0},
+		{Yaw: 0, Pitch: -8},
+		{Yaw: -20, Pitch: 15},
+	}
+
+	for _, g := range tests {
+		assertGaze(t, g, gaze.FromRotation(g.Rotation()))
+	}
+
+	// Looking right turns the forward direction towards positive X, and looking up towards
+	// positive Y.
+	forward := vmc.Vec3{X: 0, Y: 0, Z: 1}
+	assert.Greater(t, gaze.Gaze{Yaw: 10}.Rotation().Rotate(forward).X, float32(0))
+	assert.Greater(t, gaze.Gaze{Pitch: 10}.Rotation().Rotate(forward).Y, float32(0))
+}
+
+func TestLimits(t *testing.T) {
+	var limits gaze.Limits
+
+	assert.Equal(t, gaze.Gaze{Yaw: -15, Pitch: 12}, limits.Clamp(gaze.Gaze{Yaw: -40, Pitch: 30}))
+	assert.Equal(t, gaze.Gaze{Yaw: 5, Pitch: -5}, limits.Clamp(gaze.Gaze{Yaw: 5, Pitch: -5}))
+
+	limits = gaze.Limits{Left: 5, Right: 10, Up: 2, Down: 20}
+	assert.Equal(t, gaze.Gaze{Yaw: -5, Pitch: 2}, limits.Clamp(gaze.Gaze{Yaw: -40, Pitch: 30}))
+	assert.Equal(t, gaze.Gaze{Yaw: 10, Pitch: -20}, limits.Clamp(gaze.Gaze{Yaw: 40, Pitch: -30}))
+}
+
+func TestLookAt(t *testing.T) {
+	eyes := gaze.Eyes{
+		Left:  vmc.Vec3{X: -0.1, Y: 0, Z: 0},
+		Right: vmc.Vec3{X: 0.1, Y: 0, Z: 0},
+	}
+	head := vmc.Pose{Position: vmc.Vec3{X: 0, Y: 1, Z: 0}, Quaternion: vmc.Vec4{W: 1}}
+
+	// A close target in front of the face makes both eyes converge.
+	left, right := eyes.LookAt(head, vmc.Vec3{X: 0, Y: 1, Z: 0.1})
+	assertGaze(t, gaze.Gaze{Yaw: 45, Pitch: 0}, left)
+	assertGaze(t, gaze.Gaze{Yaw: -45, Pitch: 0}, right)
+
+	// With the head turned right by 90°, a target to the right is straight ahead.
+	head.Quaternion = gaze.Gaze{Yaw: 90}.Rotation()
+	left, _ = gaze.Eyes{}.LookAt(head, vmc.Vec3{X: 10, Y: 11, Z: 0})
+	assertGaze(t, gaze.Gaze{Yaw: 0, Pitch: 45}, left)
+
+	left, right, ok := eyes.Target(head, &vmc.EyeTarget{Enable: true, Position: vmc.Vec3{X: 10, Y: 1, Z: 0}})
+	require.True(t, ok)
+	assert.InDelta(t, 0, left.Pitch, 1e-3)
+	assert.Greater(t, left.Yaw, float32(0))
+	assert.Less(t, right.Yaw, float32(0))
+
+	_, _, ok = eyes.Target(head, &vmc.EyeTarget{Enable: false})
+	assert.False(t, ok)
+}
+
+func TestStage(t *testing.T) {
+	var stage gaze.Stage
+
+	eye := &vmc.BoneTransform{Name: []byte("LeftEye"), Quaternion: gaze.Gaze{Yaw: 40, Pitch: -2}.Rotation()}
+	stage.Process(eye)
+	assertGaze(t, gaze.Gaze{Yaw: 15, Pitch: -2}, gaze.FromRotation(eye.Quaternion))
+
+	within := gaze.Gaze{Yaw: 5}.Rotation()
+	eye = &vmc.BoneTransform{Name: []byte("RightEye"), Quaternion: within}
+	stage.Process(eye)
+	assert.Equal(t, within, eye.Quaternion)
+
+	head := &vmc.BoneTransform{Name: []byte("Head"), Quaternion: gaze.Gaze{Yaw: 40}.Rotation()}
+	stage.Process(head)
+	assertGaze(t, gaze.Gaze{Yaw: 40}, gaze.FromRotation(head.Quaternion))
+
+	eyeName, ok := gaze.ParseEye([]byte("RightEye"))
+	require.True(t, ok)
+	assert.Equal(t, "RightEye", eyeName.String())
+	assert.Equal(t, "Unknown(2)", gaze.Eye(2).String())
+}
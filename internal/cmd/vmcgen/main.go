@@ -0,0 +1,56 @@
+// Command vmcgen generates the VMC message types from the schema in the vmc package.
+//
+// It's invoked through go:generate in the vmc package:
+//
+//	go generate ./vmc
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dnaka91/go-vmcparser/internal/vmcgen"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "marionette.json", "path to the message schema")
+	output := flag.String("output", "marionette_gen.go", "output file for the generated code")
+	tests := flag.String("tests", "marionette_gen_test.go", "output file for the generated tests")
+
+	flag.Parse()
+
+	if err := run(*schemaPath, *output, *tests); err != nil {
+		fmt.Fprintf(os.Stderr, "vmcgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, output, tests string) error {
+	file, err := os.Open(schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed opening schema: %w", err)
+	}
+	defer file.Close()
+
+	schema, err := vmcgen.Load(file)
+	if err != nil {
+		return fmt.Errorf("failed loading schema: %w", err)
+	}
+
+	files, err := vmcgen.Generate(schema, filepath.Base(schemaPath))
+	if err != nil {
+		return fmt.Errorf("failed generating code: %w", err)
+	}
+
+	if err := os.WriteFile(output, files.Code, 0o600); err != nil {
+		return fmt.Errorf("failed writing code: %w", err)
+	}
+
+	if err := os.WriteFile(tests, files.Tests, 0o600); err != nil {
+		return fmt.Errorf("failed writing tests: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,485 @@
+// Package oscgen generates implementations of osc.Marshaler and osc.Unmarshaler for structs, so
+// that binding message arguments doesn't need any reflection.
+//
+// The struct fields are mapped by the same rules as osc.Marshal and osc.Unmarshal. As the types are
+// only inspected syntactically, the fields must use the predeclared Go types directly, instead of
+// named types that are based on them.
+package oscgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+)
+
+// ErrTypeNotFound occurs when one of the requested types isn't declared in the package.
+var ErrTypeNotFound = errors.New("type not found")
+
+// FieldError describes a struct field, that can't be mapped to an OSC argument.
+type FieldError struct {
+	Type   string // Type is the name of the struct.
+	Field  string // Field is the name of the struct field.
+	Reason string // Reason describes why the field is invalid.
+}
+
+var _ error = (*FieldError)(nil)
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("invalid field %s.%s: %s", e.Type, e.Field, e.Reason)
+}
+
+type kind int
+
+const (
+	kindInt32 kind = iota
+	kindBool
+	kindFloat32
+	kindString
+	kindBytes
+	kindInt64
+	kindFloat64
+	kindArray4
+)
+
+type field struct {
+	name     string
+	tag      byte
+	kind     kind
+	optional bool
+	pointer  bool
+}
+
+type structType struct {
+	name   string
+	fields []field
+}
+
+// Generate creates the source of a Go file, that contains the methods for all given types, which
+// are looked up in the package within dir. Test files are ignored.
+func Generate(dir string, typeNames []string) ([]byte, error) {
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, dir, func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing package: %w", err)
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected a single package in %s, found %d: %w",
+			dir, len(pkgs), fs.ErrNotExist)
+	}
+
+	var pkg *ast.Package
+	for _, p := range pkgs {
+		pkg = p
+	}
+
+	types := make([]structType, 0, len(typeNames))
+
+	for _, name := range typeNames {
+		st, err := lookupStruct(pkg, name)
+		if err != nil {
+			return nil, err
+		}
+
+		types = append(types, st)
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "// Code generated by oscgen; DO NOT EDIT.\n\npackage %s\n\n", pkg.Name)
+	buf.WriteString("import \"github.com/dnaka91/go-vmcparser/osc\"\n")
+
+	for _, st := range types {
+		writeUnmarshal(&buf, st)
+		writeMarshal(&buf, st)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed formatting generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+func lookupStruct(pkg *ast.Package, name string) (structType, error) {
+	files := make([]string, 0, len(pkg.Files))
+	for filename := range pkg.Files {
+		files = append(files, filename)
+	}
+
+	sort.Strings(files)
+
+	for _, filename := range files {
+		for _, decl := range pkg.Files[filename].Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || ts.Name.Name != name {
+					continue
+				}
+
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					return structType{}, fmt.Errorf("%s is not a struct: %w", name, ErrTypeNotFound)
+				}
+
+				return parseStruct(name, st)
+			}
+		}
+	}
+
+	return structType{}, fmt.Errorf("%s: %w", name, ErrTypeNotFound)
+}
+
+func parseStruct(name string, st *ast.StructType) (structType, error) {
+	result := structType{name: name, fields: nil}
+	optional := false
+
+	for _, astField := range st.Fields.List {
+		raw := ""
+
+		if astField.Tag != nil {
+			tag, err := strconv.Unquote(astField.Tag.Value)
+			if err != nil {
+				return result, fmt.Errorf("invalid struct tag in %s: %w", name, err)
+			}
+
+			raw = reflect.StructTag(tag).Get("osc")
+		}
+
+		for _, ident := range astField.Names {
+			if !ident.IsExported() || raw == "-" {
+				continue
+			}
+
+			f, reason := parseField(astField.Type, raw)
+			if reason != "" {
+				return result, FieldError{Type: name, Field: ident.Name, Reason: reason}
+			}
+
+			if optional && !f.optional {
+				return result, FieldError{
+					Type:   name,
+					Field:  ident.Name,
+					Reason: "required field after optional one",
+				}
+			}
+
+			f.name = ident.Name
+			optional = f.optional
+			result.fields = append(result.fields, f)
+		}
+	}
+
+	return result, nil
+}
+
+// parseField maps a single struct field, or returns the reason why it can't be mapped.
+func parseField(expr ast.Expr, raw string) (field, string) {
+	f := field{name: "", tag: 0, kind: 0, optional: false, pointer: false}
+	name, options, _ := strings.Cut(raw, ",")
+
+	switch options {
+	case "":
+	case "optional":
+		f.optional = true
+	default:
+		return f, fmt.Sprintf("unknown option `%s`", options)
+	}
+
+	if star, ok := expr.(*ast.StarExpr); ok {
+		if !f.optional {
+			return f, "pointers must be optional"
+		}
+
+		f.pointer = true
+		expr = star.X
+	}
+
+	k, ok := exprKind(expr)
+	if !ok {
+		return f, "unsupported type " + typeString(expr)
+	}
+
+	f.kind = k
+
+	switch len(name) {
+	case 0:
+		tag, ok := defaultTypeTag(k)
+		if !ok {
+			return f, "missing type tag for " + typeString(expr)
+		}
+
+		f.tag = tag
+	case 1:
+		if !typeTagFits(name[0], k) {
+			return f, fmt.Sprintf("type tag `%s` doesn't fit %s", name, typeString(expr))
+		}
+
+		f.tag = name[0]
+	default:
+		return f, fmt.Sprintf("expected a single type tag, got `%s`", name)
+	}
+
+	return f, ""
+}
+
+func exprKind(expr ast.Expr) (kind, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "int32", "rune":
+			return kindInt32, true
+		case "bool":
+			return kindBool, true
+		case "float32":
+			return kindFloat32, true
+		case "string":
+			return kindString, true
+		case "int64":
+			return kindInt64, true
+		case "float64":
+			return kindFloat64, true
+		}
+	case *ast.ArrayType:
+		if elem, ok := e.Elt.(*ast.Ident); !ok || (elem.Name != "byte" && elem.Name != "uint8") {
+			return 0, false
+		}
+
+		if e.Len == nil {
+			return kindBytes, true
+		}
+
+		if lit, ok := e.Len.(*ast.BasicLit); ok && lit.Value == "4" {
+			return kindArray4, true
+		}
+	}
+
+	return 0, false
+}
+
+// typeString renders a type expression for error messages.
+func typeString(expr ast.Expr) string {
+	var buf bytes.Buffer
+
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		return fmt.Sprintf("%T", expr)
+	}
+
+	return buf.String()
+}
+
+func defaultTypeTag(k kind) (byte, bool) {
+	switch k {
+	case kindInt32:
+		return osc.TypeTagInt, true
+	case kindBool:
+		return osc.TypeTagTrue, true
+	case kindFloat32:
+		return osc.TypeTagFloat, true
+	case kindString, kindBytes:
+		return osc.TypeTagString, true
+	case kindInt64:
+		return osc.TypeTagInt64, true
+	case kindFloat64:
+		return osc.TypeTagDouble, true
+	case kindArray4:
+		return 0, false
+	default:
+		return 0, false
+	}
+}
+
+func typeTagFits(tag byte, k kind) bool {
+	switch tag {
+	case osc.TypeTagInt:
+		return k == kindInt32 || k == kindBool
+	case osc.TypeTagChar:
+		return k == kindInt32
+	case osc.TypeTagFloat:
+		return k == kindFloat32
+	case osc.TypeTagString, osc.TypeTagSymbol, osc.TypeTagBlob:
+		return k == kindString || k == kindBytes
+	case osc.TypeTagInt64, osc.TypeTagTimeTag:
+		return k == kindInt64
+	case osc.TypeTagDouble:
+		return k == kindFloat64
+	case osc.TypeTagRgba, osc.TypeTagMidi:
+		return k == kindArray4
+	case osc.TypeTagTrue:
+		return k == kindBool
+	default:
+		return false
+	}
+}
+
+func writeUnmarshal(buf *bytes.Buffer, st structType) {
+	expected := make([]byte, 0, len(st.fields))
+	required := 0
+
+	for _, f := range st.fields {
+		expected = append(expected, f.tag)
+
+		if !f.optional {
+			required++
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// UnmarshalOSC implements osc.Unmarshaler.\n")
+	fmt.Fprintf(buf, "func (v *%s) UnmarshalOSC(msg *osc.Message) error {\n", st.name)
+	fmt.Fprintf(buf, "if err := osc.CheckTypeTags(msg.TypeTags, %q, %d); err != nil {\n",
+		expected, required)
+	fmt.Fprint(buf, "return err\n}\n\n")
+
+	for i, f := range st.fields {
+		arg := fmt.Sprintf("msg.Arguments[%d]", i)
+
+		switch {
+		case !f.optional:
+			fmt.Fprintf(buf, "v.%s = %s\n", f.name, argumentExpr(f, arg))
+		case f.pointer:
+			fmt.Fprintf(buf, "\nif len(msg.Arguments) > %d {\n", i)
+			fmt.Fprintf(buf, "value := %s\nv.%s = &value\n", argumentExpr(f, arg), f.name)
+			fmt.Fprintf(buf, "} else {\nv.%s = nil\n}\n", f.name)
+		default:
+			fmt.Fprintf(buf, "\nif len(msg.Arguments) > %d {\n", i)
+			fmt.Fprintf(buf, "v.%s = %s\n", f.name, argumentExpr(f, arg))
+			fmt.Fprintf(buf, "} else {\nv.%s = %s\n}\n", f.name, zeroValue(f.kind))
+		}
+	}
+
+	buf.WriteString("\nreturn nil\n}\n")
+}
+
+func writeMarshal(buf *bytes.Buffer, st structType) {
+	fmt.Fprintf(buf, "\n// AppendOSC implements osc.Marshaler.\n")
+	fmt.Fprintf(buf, "func (v *%s) AppendOSC(typeTags, arguments []byte) ([]byte, []byte, error) {\n",
+		st.name)
+
+	for _, f := range st.fields {
+		value := "v." + f.name
+
+		if f.pointer {
+			fmt.Fprintf(buf, "if %s == nil {\nreturn typeTags, arguments, nil\n}\n\n", value)
+			value = "*" + value
+		}
+
+		switch {
+		case f.tag == 'T':
+			fmt.Fprintf(buf, "if %s {\ntypeTags = append(typeTags, 'T')\n", value)
+			fmt.Fprint(buf, "} else {\ntypeTags = append(typeTags, 'F')\n}\n\n")
+		case f.tag == 'i' && f.kind == kindBool:
+			fmt.Fprintf(buf, "typeTags = append(typeTags, 'i')\n")
+			fmt.Fprintf(buf, "if %s {\narguments = osc.AppendInt(arguments, 1)\n", value)
+			fmt.Fprint(buf, "} else {\narguments = osc.AppendInt(arguments, 0)\n}\n\n")
+		default:
+			fmt.Fprintf(buf, "typeTags = append(typeTags, '%c')\n", f.tag)
+			fmt.Fprintf(buf, "arguments = %s\n\n", appendExpr(f, value))
+		}
+	}
+
+	buf.WriteString("return typeTags, arguments, nil\n}\n")
+}
+
+func argumentExpr(f field, arg string) string {
+	switch {
+	case f.tag == 'i' && f.kind == kindBool:
+		return arg + ".(int32) != 0"
+	case f.kind == kindString:
+		return "string(" + arg + ".([]byte))"
+	default:
+		return arg + ".(" + goType(f.kind) + ")"
+	}
+}
+
+func appendExpr(f field, value string) string {
+	if f.kind == kindString && f.tag == 'b' {
+		value = "[]byte(" + value + ")"
+	}
+
+	switch f.tag {
+	case 'i':
+		return "osc.AppendInt(arguments, " + value + ")"
+	case 'c':
+		return "osc.AppendChar(arguments, " + value + ")"
+	case 'f':
+		return "osc.AppendFloat(arguments, " + value + ")"
+	case 's', 'S':
+		if f.kind == kindBytes {
+			return "osc.AppendStringBytes(arguments, " + value + ")"
+		}
+
+		return "osc.AppendString(arguments, " + value + ")"
+	case 'b':
+		return "osc.AppendBlob(arguments, " + value + ")"
+	case 'h':
+		return "osc.AppendInt64(arguments, " + value + ")"
+	case 't':
+		return "osc.AppendTimeTag(arguments, " + value + ")"
+	case 'd':
+		return "osc.AppendDouble(arguments, " + value + ")"
+	case 'r':
+		return "osc.AppendRgba(arguments, " + value + ")"
+	case 'm':
+		return "osc.AppendMidi(arguments, " + value + ")"
+	default:
+		return "arguments"
+	}
+}
+
+func goType(k kind) string {
+	switch k {
+	case kindInt32:
+		return "int32"
+	case kindBool:
+		return "bool"
+	case kindFloat32:
+		return "float32"
+	case kindString, kindBytes:
+		return "[]byte"
+	case kindInt64:
+		return "int64"
+	case kindFloat64:
+		return "float64"
+	case kindArray4:
+		return "[4]byte"
+	default:
+		return ""
+	}
+}
+
+func zeroValue(k kind) string {
+	switch k {
+	case kindInt32, kindFloat32, kindInt64, kindFloat64:
+		return "0"
+	case kindBool:
+		return "false"
+	case kindString:
+		return `""`
+	case kindBytes:
+		return "nil"
+	case kindArray4:
+		return "[4]byte{}"
+	default:
+		return ""
+	}
+}
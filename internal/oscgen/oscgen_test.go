@@ -0,0 +1,71 @@
+package oscgen_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/internal/oscgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateUpToDate(t *testing.T) {
+	want, err := os.ReadFile("sample/sample_osc.go")
+	require.NoError(t, err)
+
+	got, err := oscgen.Generate("sample", []string{"Device", "Everything"})
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got), "sample is outdated, run `go generate ./...`")
+}
+
+func TestGenerateTypeNotFound(t *testing.T) {
+	dir := writePackage(t, "type NotStruct int32\n")
+
+	_, err := oscgen.Generate(dir, []string{"Missing"})
+	assert.ErrorIs(t, err, oscgen.ErrTypeNotFound)
+
+	_, err = oscgen.Generate(dir, []string{"NotStruct"})
+	assert.ErrorIs(t, err, oscgen.ErrTypeNotFound)
+}
+
+func TestGenerateFieldErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		reason string
+	}{
+		{"unknown tag", "A int32 `osc:\"x\"`", "type tag `x` doesn't fit int32"},
+		{"mismatching tag", "A float32 `osc:\"i\"`", "type tag `i` doesn't fit float32"},
+		{"unknown option", "A int32 `osc:\"i,required\"`", "unknown option `required`"},
+		{"multiple tags", "A int32 `osc:\"ii\"`", "expected a single type tag, got `ii`"},
+		{"unsupported type", "A int", "unsupported type int"},
+		{"named type", "A Other", "unsupported type Other"},
+		{"missing tag", "A [4]byte", "missing type tag for [4]byte"},
+		{"required pointer", "A *int32", "pointers must be optional"},
+		{"required after optional", "A int32 `osc:\",optional\"`\nB int32", "required field after optional one"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writePackage(t, "type Other int32\n\ntype Msg struct {\n"+tt.fields+"\n}\n")
+
+			_, err := oscgen.Generate(dir, []string{"Msg"})
+
+			var fieldErr oscgen.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.reason, fieldErr.Reason)
+		})
+	}
+}
+
+func writePackage(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "types.go"), []byte("package types\n\n"+content), 0o600)
+	require.NoError(t, err)
+
+	return dir
+}
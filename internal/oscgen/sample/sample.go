@@ -0,0 +1,36 @@
+// Package sample contains types with generated OSC bindings, to verify the oscgen output.
+package sample
+
+//go:generate go run github.com/dnaka91/go-vmcparser/cmd/oscgen -type Device,Everything -output sample_osc.go
+
+// Device is a made-up message, that was extended with optional arguments over time, similar to
+// several of the VMC messages.
+type Device struct {
+	Serial     string
+	Position   [3]float32 `osc:"-"`
+	X          float32
+	Y          float32
+	Z          float32
+	Local      *bool   `osc:"i,optional"`
+	Confidence float32 `osc:",optional"`
+}
+
+// Everything covers every supported combination of Go type and type tag.
+type Everything struct {
+	Int      int32
+	Char     rune `osc:"c"`
+	IntBool  bool `osc:"i"`
+	Bool     bool
+	Float    float32
+	String   string
+	Symbol   []byte `osc:"S"`
+	Blob     []byte `osc:"b"`
+	BlobText string `osc:"b"`
+	Int64    int64
+	TimeTag  int64 `osc:"t"`
+	Double   float64
+	Rgba     [4]byte `osc:"r"`
+	Midi     [4]byte `osc:"m"`
+	Flag     *bool   `osc:",optional"`
+	Extra    *string `osc:",optional"`
+}
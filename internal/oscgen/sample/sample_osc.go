@@ -0,0 +1,172 @@
+// Code generated by oscgen; DO NOT EDIT.
+
+package sample
+
+import "github.com/dnaka91/go-vmcparser/osc"
+
+// UnmarshalOSC implements osc.Unmarshaler.
+func (v *Device) UnmarshalOSC(msg *osc.Message) error {
+	if err := osc.CheckTypeTags(msg.TypeTags, "sfffif", 4); err != nil {
+		return err
+	}
+
+	v.Serial = string(msg.Arguments[0].([]byte))
+	v.X = msg.Arguments[1].(float32)
+	v.Y = msg.Arguments[2].(float32)
+	v.Z = msg.Arguments[3].(float32)
+
+	if len(msg.Arguments) > 4 {
+		value := msg.Arguments[4].(int32) != 0
+		v.Local = &value
+	} else {
+		v.Local = nil
+	}
+
+	if len(msg.Arguments) > 5 {
+		v.Confidence = msg.Arguments[5].(float32)
+	} else {
+		v.Confidence = 0
+	}
+
+	return nil
+}
+
+// AppendOSC implements osc.Marshaler.
+func (v *Device) AppendOSC(typeTags, arguments []byte) ([]byte, []byte, error) {
+	typeTags = append(typeTags, 's')
+	arguments = osc.AppendString(arguments, v.Serial)
+
+	typeTags = append(typeTags, 'f')
+	arguments = osc.AppendFloat(arguments, v.X)
+
+	typeTags = append(typeTags, 'f')
+	arguments = osc.AppendFloat(arguments, v.Y)
+
+	typeTags = append(typeTags, 'f')
+	arguments = osc.AppendFloat(arguments, v.Z)
+
+	if v.Local == nil {
+		return typeTags, arguments, nil
+	}
+
+	typeTags = append(typeTags, 'i')
+	if *v.Local {
+		arguments = osc.AppendInt(arguments, 1)
+	} else {
+		arguments = osc.AppendInt(arguments, 0)
+	}
+
+	typeTags = append(typeTags, 'f')
+	arguments = osc.AppendFloat(arguments, v.Confidence)
+
+	return typeTags, arguments, nil
+}
+
+// UnmarshalOSC implements osc.Unmarshaler.
+func (v *Everything) UnmarshalOSC(msg *osc.Message) error {
+	if err := osc.CheckTypeTags(msg.TypeTags, "iciTfsSbbhtdrmTs", 14); err != nil {
+		return err
+	}
+
+	v.Int = msg.Arguments[0].(int32)
+	v.Char = msg.Arguments[1].(int32)
+	v.IntBool = msg.Arguments[2].(int32) != 0
+	v.Bool = msg.Arguments[3].(bool)
+	v.Float = msg.Arguments[4].(float32)
+	v.String = string(msg.Arguments[5].([]byte))
+	v.Symbol = msg.Arguments[6].([]byte)
+	v.Blob = msg.Arguments[7].([]byte)
+	v.BlobText = string(msg.Arguments[8].([]byte))
+	v.Int64 = msg.Arguments[9].(int64)
+	v.TimeTag = msg.Arguments[10].(int64)
+	v.Double = msg.Arguments[11].(float64)
+	v.Rgba = msg.Arguments[12].([4]byte)
+	v.Midi = msg.Arguments[13].([4]byte)
+
+	if len(msg.Arguments) > 14 {
+		value := msg.Arguments[14].(bool)
+		v.Flag = &value
+	} else {
+		v.Flag = nil
+	}
+
+	if len(msg.Arguments) > 15 {
+		value := string(msg.Arguments[15].([]byte))
+		v.Extra = &value
+	} else {
+		v.Extra = nil
+	}
+
+	return nil
+}
+
+// AppendOSC implements osc.Marshaler.
+func (v *Everything) AppendOSC(typeTags, arguments []byte) ([]byte, []byte, error) {
+	typeTags = append(typeTags, 'i')
+	arguments = osc.AppendInt(arguments, v.Int)
+
+	typeTags = append(typeTags, 'c')
+	arguments = osc.AppendChar(arguments, v.Char)
+
+	typeTags = append(typeTags, 'i')
+	if v.IntBool {
+		arguments = osc.AppendInt(arguments, 1)
+	} else {
+		arguments = osc.AppendInt(arguments, 0)
+	}
+
+	if v.Bool {
+		typeTags = append(typeTags, 'T')
+	} else {
+		typeTags = append(typeTags, 'F')
+	}
+
+	typeTags = append(typeTags, 'f')
+	arguments = osc.AppendFloat(arguments, v.Float)
+
+	typeTags = append(typeTags, 's')
+	arguments = osc.AppendString(arguments, v.String)
+
+	typeTags = append(typeTags, 'S')
+	arguments = osc.AppendStringBytes(arguments, v.Symbol)
+
+	typeTags = append(typeTags, 'b')
+	arguments = osc.AppendBlob(arguments, v.Blob)
+
+	typeTags = append(typeTags, 'b')
+	arguments = osc.AppendBlob(arguments, []byte(v.BlobText))
+
+	typeTags = append(typeTags, 'h')
+	arguments = osc.AppendInt64(arguments, v.Int64)
+
+	typeTags = append(typeTags, 't')
+	arguments = osc.AppendTimeTag(arguments, v.TimeTag)
+
+	typeTags = append(typeTags, 'd')
+	arguments = osc.AppendDouble(arguments, v.Double)
+
+	typeTags = append(typeTags, 'r')
+	arguments = osc.AppendRgba(arguments, v.Rgba)
+
+	typeTags = append(typeTags, 'm')
+	arguments = osc.AppendMidi(arguments, v.Midi)
+
+	if v.Flag == nil {
+		return typeTags, arguments, nil
+	}
+
+	if *v.Flag {
+		typeTags = append(typeTags, 'T')
+	} else {
+		typeTags = append(typeTags, 'F')
+	}
+
+	if v.Extra == nil {
+		return typeTags, arguments, nil
+	}
+
+	typeTags = append(typeTags, 's')
+	arguments = osc.AppendString(arguments, *v.Extra)
+
+	return typeTags, arguments, nil
+}
@@ -0,0 +1,97 @@
+package sample_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/internal/oscgen/sample"
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The reflect types have the same fields, but none of the generated methods, so the osc package
+// falls back to reflection for them.
+type (
+	reflectDevice     sample.Device
+	reflectEverything sample.Everything
+)
+
+func TestDeviceMatchesReflection(t *testing.T) {
+	local := true
+
+	tests := []sample.Device{
+		{Serial: "a", X: 1, Y: 2, Z: 3},
+		{Serial: "b", X: 1, Y: 2, Z: 3, Local: &local},
+		{Serial: "c", X: 1, Y: 2, Z: 3, Local: &local, Confidence: 0.5},
+	}
+
+	for _, input := range tests {
+		input := input
+
+		generated, err := osc.Marshal("/device", &input)
+		require.NoError(t, err)
+
+		reflected, err := osc.Marshal("/device", reflectDevice(input))
+		require.NoError(t, err)
+		assert.Equal(t, reflected, generated)
+
+		var viaGenerated sample.Device
+		var viaReflection reflectDevice
+
+		packet, _, err := osc.ReadPacket(generated)
+		require.NoError(t, err)
+		require.NoError(t, osc.Unmarshal(packet.Message, &viaGenerated))
+		require.NoError(t, osc.Unmarshal(packet.Message, &viaReflection))
+		assert.Equal(t, input, viaGenerated)
+		assert.Equal(t, sample.Device(viaReflection), viaGenerated)
+	}
+}
+
+func TestEverythingMatchesReflection(t *testing.T) {
+	flag := false
+	extra := "extra"
+
+	input := sample.Everything{
+		Int:      1,
+		Char:     'c',
+		IntBool:  true,
+		Bool:     true,
+		Float:    2.5,
+		String:   "string",
+		Symbol:   []byte("symbol"),
+		Blob:     []byte{1, 2, 3},
+		BlobText: "text",
+		Int64:    -3,
+		TimeTag:  4,
+		Double:   5.5,
+		Rgba:     [4]byte{1, 2, 3, 4},
+		Midi:     [4]byte{5, 6, 7, 8},
+		Flag:     &flag,
+		Extra:    &extra,
+	}
+
+	generated, err := osc.Marshal("/everything", &input)
+	require.NoError(t, err)
+
+	reflected, err := osc.Marshal("/everything", reflectEverything(input))
+	require.NoError(t, err)
+	assert.Equal(t, reflected, generated)
+
+	var output sample.Everything
+
+	packet, _, err := osc.ReadPacket(generated)
+	require.NoError(t, err)
+	require.NoError(t, osc.Unmarshal(packet.Message, &output))
+	assert.Equal(t, input, output)
+}
+
+func TestGeneratedTypeTagsMismatch(t *testing.T) {
+	var output sample.Device
+
+	packet, _, err := osc.ReadPacket(osc.AppendMessage(nil, "/device", []byte("s"), osc.AppendString(nil, "a")))
+	require.NoError(t, err)
+
+	var mismatch osc.TypeTagsMismatchError
+	require.ErrorAs(t, osc.Unmarshal(packet.Message, &output), &mismatch)
+	assert.Equal(t, osc.TypeTagsMismatchError{Found: []byte("s"), Expected: "sfffif", Required: 4}, mismatch)
+}
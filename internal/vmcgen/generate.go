@@ -0,0 +1,722 @@
+package vmcgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// Files contains the generated sources.
+type Files struct {
+	Code  []byte // Code contains the message types and their functions.
+	Tests []byte // Tests contains the round-trip tests for all messages.
+}
+
+// Generate creates the message code and tests for the schema. The source name is mentioned in the
+// header of the generated files.
+func Generate(schema *Schema, source string) (Files, error) {
+	g := generator{schema: schema, buf: bytes.Buffer{}}
+	g.writeCode(source)
+
+	code, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return Files{}, fmt.Errorf("failed formatting generated code: %w", err)
+	}
+
+	g.buf.Reset()
+	g.writeTests(source)
+
+	tests, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return Files{}, fmt.Errorf("failed formatting generated tests: %w", err)
+	}
+
+	return Files{Code: code, Tests: tests}, nil
+}
+
+type generator struct {
+	schema *Schema
+	buf    bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) doc(doc string) {
+	for _, line := range wrap(doc, 97) {
+		g.printf("// %s\n", line)
+	}
+}
+
+func (g *generator) writeCode(source string) {
+	g.printf("// Code generated by vmcgen from %s; DO NOT EDIT.\n\n", source)
+	g.printf("package %s\n\n", g.schema.Package)
+	g.printf("import (\n\"fmt\"\n\n\"github.com/dnaka91/go-vmcparser/osc\"\n)\n\n")
+
+	g.doc(g.schema.AddressDoc)
+	g.printf("const (\n")
+
+	for i := range g.schema.Messages {
+		m := &g.schema.Messages[i]
+
+		if m.Address != "" {
+			g.printf("Address%s = %q\n", m.Name, m.Address)
+		}
+
+		for _, address := range m.Addresses {
+			g.printf("%s = %q\n", address.Const, address.Address)
+		}
+	}
+
+	g.printf(")\n")
+
+	for i := range g.schema.Enums {
+		g.writeEnum(&g.schema.Enums[i])
+	}
+
+	for i := range g.schema.Messages {
+		g.writeMessage(&g.schema.Messages[i])
+	}
+
+	g.writeStorage()
+}
+
+func (g *generator) writeEnum(e *Enum) {
+	recv := enumReceiver(e.Name)
+
+	g.printf("\n")
+	g.doc(e.Doc)
+	g.printf("type %s uint8\n\n", e.Name)
+	g.doc(e.ValuesDoc)
+	g.printf("const (\n")
+
+	for i, value := range e.Values {
+		if i == 0 {
+			g.printf("%s%s %s = iota\n", e.Name, value, e.Name)
+		} else {
+			g.printf("%s%s\n", e.Name, value)
+		}
+	}
+
+	g.printf(")\n\n")
+
+	if g.enumOnWire(e.Name) {
+		g.printf("func (%s %s) isValid() bool {\n", recv, e.Name)
+		g.printf("return %s <= %s%s\n}\n\n", recv, e.Name, e.Values[len(e.Values)-1])
+	}
+
+	g.printf("func (%s %s) String() string {\nswitch %s {\n", recv, e.Name, recv)
+
+	for _, value := range e.Values {
+		g.printf("case %s%s:\nreturn %q\n", e.Name, value, value)
+	}
+
+	g.printf("default:\nreturn fmt.Sprintf(\"Unknown(%%d)\", uint8(%s))\n}\n}\n", recv)
+}
+
+func (g *generator) enumOnWire(name string) bool {
+	for _, m := range g.schema.Messages {
+		for _, f := range m.Fields {
+			if f.Type == name && !f.FromAddress {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (g *generator) writeMessage(m *Message) {
+	recv := messageReceiver(m.Name)
+
+	g.printf("\n")
+	g.doc(m.Doc)
+
+	if len(m.Fields) == 0 && !m.hasVersion() {
+		g.printf("type %s struct{}\n", m.Name)
+	} else {
+		g.printf("type %s struct {\n", m.Name)
+
+		for i := range m.Fields {
+			f := &m.Fields[i]
+			g.printf("%s %s", f.Name, g.fieldType(m, f))
+
+			if f.Doc != "" {
+				g.printf(" // %s", f.Doc)
+			}
+
+			g.printf("\n")
+		}
+
+		if m.hasVersion() {
+			g.printf("Version ProtocolVersion // Version is the protocol version of the received variant.\n")
+		}
+
+		g.printf("}\n")
+	}
+
+	g.printf("\nfunc (%s *%s) isMessage() {}\n", recv, m.Name)
+	g.writeProtocolVersion(m, recv)
+	g.writeString(m, recv)
+	g.writeAppend(m, recv)
+
+	if len(m.Addresses) > 0 {
+		g.writeAddress(m, recv)
+	}
+
+	g.writeParse(m)
+}
+
+func (g *generator) writeProtocolVersion(m *Message, recv string) {
+	switch {
+	case m.hasVersion():
+		g.printf("\n// ProtocolVersion tells the version of the received message variant.\n")
+		g.printf("func (%s *%s) ProtocolVersion() ProtocolVersion {\nreturn %s.Version\n}\n",
+			recv, m.Name, recv)
+	case hasAddressVersions(m):
+		g.printf("\n// ProtocolVersion tells the version that introduced the message address.\n")
+		g.printf("func (%s *%s) ProtocolVersion() ProtocolVersion {\nswitch %s.address() {\n",
+			recv, m.Name, recv)
+
+		for _, version := range addressVersions(m) {
+			var consts []string
+
+			for _, address := range m.Addresses {
+				if address.Version == version {
+					consts = append(consts, address.Const)
+				}
+			}
+
+			g.printf("case %s:\nreturn Protocol%s\n", strings.Join(consts, ", "), version)
+		}
+
+		g.printf("default:\nreturn Protocol%s\n}\n}\n", m.Variants[0])
+	default:
+		g.printf("\n// ProtocolVersion tells the version that introduced the message.\n")
+		g.printf("func (%s *%s) ProtocolVersion() ProtocolVersion {\nreturn Protocol%s\n}\n",
+			recv, m.Name, m.Variants[0])
+	}
+}
+
+func (g *generator) writeString(m *Message, recv string) {
+	g.printf("\nfunc (%s *%s) String() string {\n", recv, m.Name)
+
+	if len(m.Fields) == 0 && !m.hasVersion() {
+		g.printf("return %q\n}\n", m.Name+" {}")
+
+		return
+	}
+
+	formats := make([]string, 0, len(m.Fields)+1)
+	args := make([]string, 0, len(m.Fields)+1)
+
+	for i := range m.Fields {
+		f := &m.Fields[i]
+
+		switch {
+		case f.Type == "string" && m.optional(f):
+			formats = append(formats, f.Name+": %s")
+			args = append(args, "quoteOptional("+recv+"."+f.Name+")")
+		case f.Type == "string":
+			formats = append(formats, f.Name+": %q")
+			args = append(args, recv+"."+f.Name)
+		default:
+			formats = append(formats, f.Name+": %v")
+			args = append(args, recv+"."+f.Name)
+		}
+	}
+
+	if m.hasVersion() {
+		formats = append(formats, "Version: %v")
+		args = append(args, recv+".Version")
+	}
+
+	g.printf("return fmt.Sprintf(\n%q,\n%s,\n)\n}\n",
+		m.Name+" { "+strings.Join(formats, ", ")+" }",
+		strings.Join(args, ",\n"),
+	)
+}
+
+func (g *generator) writeAppend(m *Message, recv string) {
+	g.printf("\n// AppendMessage appends the message in its OSC encoding to the buffer.")
+
+	if m.hasVersion() {
+		g.printf(" The variant is selected\n")
+		g.printf("// by the Version field. If the version is unknown, the latest variant, that has all " +
+			"its optional\n")
+		g.printf("// fields present, is used.")
+	}
+
+	g.printf("\nfunc (%s *%s) AppendMessage(buf []byte) []byte {\n", recv, m.Name)
+
+	if m.hasVersion() {
+		g.printf("version := %s.Version\nif version == ProtocolVersionUnknown {\nversion = Protocol%s\n",
+			recv, m.Variants[0])
+
+		for _, variant := range m.Variants[1:] {
+			conditions := make([]string, 0, 1)
+			for _, f := range optionalFields(m, variant) {
+				conditions = append(conditions, recv+"."+f.Name+".Valid")
+			}
+
+			g.printf("\nif %s {\nversion = Protocol%s\n", strings.Join(conditions, " && "), variant)
+		}
+
+		g.printf("%s}\n\n", strings.Repeat("}\n", len(m.Variants)-1))
+	}
+
+	if m.Address != "" {
+		g.printf("buf = osc.AppendString(buf, Address%s)\n", m.Name)
+	} else {
+		g.printf("buf = osc.AppendString(buf, %s.address())\n", recv)
+	}
+
+	if m.hasVersion() {
+		g.printf("\nswitch {\n")
+
+		for i := len(m.Variants) - 1; i > 0; i-- {
+			g.printf("case version >= Protocol%s:\n", m.Variants[i])
+			g.printf("buf = osc.AppendString(buf, %q)\n", ","+typeTags(m, m.Variants[i]))
+		}
+
+		g.printf("default:\nbuf = osc.AppendString(buf, %q)\n}\n\n", ","+typeTags(m, m.Variants[0]))
+	} else {
+		g.printf("buf = osc.AppendString(buf, %q)\n", ","+typeTags(m, m.Variants[0]))
+	}
+
+	for _, f := range m.wireFields() {
+		if !m.optional(&f) {
+			g.printf("buf = %s\n", appendExpr(f, recv+"."+f.Name))
+		}
+	}
+
+	for _, variant := range m.Variants[1:] {
+		g.printf("\nif version >= Protocol%s {\n", variant)
+
+		for _, f := range optionalFields(m, variant) {
+			g.printf("buf = %s\n", appendExpr(f, recv+"."+f.Name+".Value"))
+		}
+
+		g.printf("}\n")
+	}
+
+	g.printf("\nreturn buf\n}\n")
+}
+
+func (g *generator) writeAddress(m *Message, recv string) {
+	g.printf("\n// address selects the message address, that matches the fields which aren't " +
+		"transmitted as\n")
+	g.printf("// arguments.\n")
+	g.printf("func (%s *%s) address() string {\nswitch {\n", recv, m.Name)
+
+	for _, address := range m.Addresses {
+		conditions := make([]string, 0, len(address.Set))
+
+		for _, f := range m.Fields {
+			value, ok := address.Set[f.Name]
+			if !ok {
+				continue
+			}
+
+			switch {
+			case f.Type == "bool" && value == "true":
+				conditions = append(conditions, recv+"."+f.Name)
+			case f.Type == "bool" && value == "false":
+				conditions = append(conditions, "!"+recv+"."+f.Name)
+			default:
+				conditions = append(conditions, recv+"."+f.Name+" == "+value)
+			}
+		}
+
+		g.printf("case %s:\nreturn %s\n", strings.Join(conditions, " && "), address.Const)
+	}
+
+	g.printf("default:\nreturn %s\n}\n}\n", m.Addresses[0].Const)
+}
+
+func (g *generator) writeParse(m *Message) {
+	var params []string
+
+	for _, f := range m.Fields {
+		if f.FromAddress {
+			params = append(params, localName(f.Name)+" "+g.fieldType(m, &f))
+		}
+	}
+
+	if len(params) > 0 {
+		g.printf("\nfunc parse%s(\ntags, data []byte,\n", m.Name)
+		g.printf("%s,\nmsg *%s,\n) error {\n", strings.Join(params, ",\n"), m.Name)
+	} else {
+		g.printf("\nfunc parse%s(tags, data []byte, msg *%s) error {\n", m.Name, m.Name)
+	}
+
+	g.writeTypeTagsCheck(m)
+
+	wire := m.wireFields()
+	required := 0
+
+	for _, f := range wire {
+		if !m.optional(&f) {
+			required++
+		}
+	}
+
+	g.writeDecodeFields(wire[:required], required < len(wire))
+
+	if len(m.Fields) == 0 && !m.hasVersion() {
+		g.printf("*msg = %s{}\n", m.Name)
+	} else {
+		g.printf("*msg = %s{\n", m.Name)
+
+		for i := range m.Fields {
+			f := &m.Fields[i]
+
+			if m.optional(f) {
+				g.printf("%s: None[%s](),\n", f.Name, baseType(f))
+			} else {
+				g.printf("%s: %s,\n", f.Name, localName(f.Name))
+			}
+		}
+
+		if m.hasVersion() {
+			g.printf("Version: version,\n")
+		}
+
+		g.printf("}\n")
+	}
+
+	offset := required
+
+	for _, variant := range m.Variants[1:] {
+		fields := optionalFields(m, variant)
+		offset += len(fields)
+
+		g.printf("\nif version >= Protocol%s {\n", variant)
+		g.writeDecodeFields(fields, offset < len(wire))
+
+		for _, f := range fields {
+			g.printf("msg.%s = Some(%s)\n", f.Name, localName(f.Name))
+		}
+
+		g.printf("}\n")
+	}
+
+	g.printf("\nreturn nil\n}\n")
+}
+
+func (g *generator) writeTypeTagsCheck(m *Message) {
+	if !m.hasVersion() {
+		tags := typeTags(m, m.Variants[0])
+		expected := "nil"
+
+		if tags != "" {
+			expected = fmt.Sprintf("[]string{%q}", tags)
+		}
+
+		g.printf("if string(tags) != %q {\nreturn InvalidTypeTagsError{Found: tags, Expected: %s}\n}\n\n",
+			tags, expected)
+
+		return
+	}
+
+	consts := make([]string, 0, len(m.Variants))
+
+	g.printf("const (\n")
+
+	for _, variant := range m.Variants {
+		g.printf("typeTags%s = %q\n", variant, typeTags(m, variant))
+		consts = append(consts, "typeTags"+variant)
+	}
+
+	g.printf(")\n\nvar version ProtocolVersion\n\nswitch string(tags) {\n")
+
+	for _, variant := range m.Variants {
+		g.printf("case typeTags%s:\nversion = Protocol%s\n", variant, variant)
+	}
+
+	g.printf("default:\nreturn InvalidTypeTagsError{\nFound: tags,\n")
+	g.printf("Expected: []string{%s},\n}\n}\n\n", strings.Join(consts, ", "))
+}
+
+// writeDecodeFields reads the fields into local variables. Consecutive fields of fixed size are
+// read as a single chunk, after checking the remaining data once.
+func (g *generator) writeDecodeFields(fields []Field, more bool) {
+	var chunk []Field
+
+	flush := func(more bool) {
+		if len(chunk) == 0 {
+			return
+		}
+
+		size := 0
+		for _, f := range chunk {
+			size += fieldSize(f)
+		}
+
+		g.printf("if len(data) < %d {\n"+
+			"return InvalidBufferLengthError{Length: len(data), Expected: %d}\n}\n\n", size, size)
+
+		offset := 0
+
+		for _, f := range chunk {
+			g.writeDecodeFixed(f, offset)
+			offset += fieldSize(f)
+		}
+
+		if more {
+			g.printf("data = data[%d:]\n", size)
+		}
+
+		g.printf("\n")
+
+		chunk = nil
+	}
+
+	for i, f := range fields {
+		if f.Type != "string" {
+			chunk = append(chunk, f)
+
+			continue
+		}
+
+		flush(true)
+
+		if i < len(fields)-1 || more {
+			g.printf("%s, newData, err := getString(data)\n", localName(f.Name))
+			g.printf("if err != nil {\nreturn err\n}\ndata = newData\n\n")
+		} else {
+			g.printf("%s, _, err := getString(data)\nif err != nil {\nreturn err\n}\n\n", localName(f.Name))
+		}
+	}
+
+	flush(more)
+}
+
+func (g *generator) writeDecodeFixed(f Field, offset int) {
+	local := localName(f.Name)
+	window := fmt.Sprintf("data[%d:%d]", offset, offset+fieldSize(f))
+
+	switch f.Type {
+	case "bool":
+		g.printf("%s := getInt32(%s) == 1\n", local, window)
+	case "int32":
+		g.printf("%s := getInt32(%s)\n", local, window)
+	case "float32":
+		g.printf("%s := getFloat32(%s)\n", local, window)
+	case "vec3":
+		g.printf("%s := getVec3(%s)\n", local, window)
+	case "vec4":
+		g.printf("%s := getVec4(%s)\n", local, window)
+	default:
+		enum := g.schema.enum(f.Type)
+		raw := "raw" + f.Name
+
+		g.printf("\n%s := getInt32(%s)\n%s := %s(%s)\n", raw, window, local, f.Type, raw)
+		g.printf("if !%s.isValid() {\nreturn InvalidEnumValueError{\n", local)
+		g.printf("Name: %q,\nValue: %s,\n}\n}\n\n", enum.ErrorName, raw)
+	}
+}
+
+func (g *generator) writeStorage() {
+	g.printf("\n// messageStorage keeps a reusable value for each message type, so a Decoder only " +
+		"allocates each\n")
+	g.printf("// of them once.\n")
+	g.printf("type messageStorage struct {\n")
+
+	for _, m := range g.schema.Messages {
+		g.printf("%s *%s\n", localName(m.Name), m.Name)
+	}
+
+	g.printf("}\n\n")
+	g.printf("// decode parses the arguments of the message with the given address into the " +
+		"storage. It returns\n")
+	g.printf("// false, if the address doesn't belong to any of the known messages.\n")
+	g.printf("func (s *messageStorage) decode(address, tags, data []byte) (Message, bool, error) {\n")
+	g.printf("switch string(address) {\n")
+
+	for i := range g.schema.Messages {
+		m := &g.schema.Messages[i]
+
+		if m.Address != "" {
+			g.printf("case Address%s:\n", m.Name)
+			g.printf("msg, err := decodeInto(&s.%s, tags, data, parse%s)\n\nreturn msg, true, err\n",
+				localName(m.Name), m.Name)
+
+			continue
+		}
+
+		for _, address := range m.Addresses {
+			var args []string
+
+			for _, f := range m.Fields {
+				if f.FromAddress {
+					args = append(args, address.Set[f.Name])
+				}
+			}
+
+			g.printf("case %s:\n", address.Const)
+			g.printf("msg, err := decodeInto(&s.%s, tags, data, ", localName(m.Name))
+			g.printf("func(tags, data []byte, msg *%s) error {\n", m.Name)
+			g.printf("return parse%s(tags, data, %s, msg)\n})\n\nreturn msg, true, err\n",
+				m.Name, strings.Join(args, ", "))
+		}
+	}
+
+	g.printf("default:\nreturn nil, false, nil\n}\n}\n")
+}
+
+func (g *generator) fieldType(m *Message, f *Field) string {
+	if m.optional(f) {
+		return "Optional[" + baseType(f) + "]"
+	}
+
+	return baseType(f)
+}
+
+func baseType(f *Field) string {
+	switch f.Type {
+	case "bool", "int32", "float32":
+		return f.Type
+	case "string":
+		return "[]byte"
+	case "vec3":
+		return "Vec3"
+	case "vec4":
+		return "Vec4"
+	default:
+		return f.Type
+	}
+}
+
+func fieldSize(f Field) int {
+	switch f.Type {
+	case "vec3":
+		return 12
+	case "vec4":
+		return 16
+	default:
+		return 4
+	}
+}
+
+func typeTags(m *Message, variant string) string {
+	var sb strings.Builder
+
+	limit := variantIndex(m, variant)
+
+	for _, f := range m.wireFields() {
+		if f.Since != "" && variantIndex(m, f.Since) > limit {
+			continue
+		}
+
+		switch f.Type {
+		case "float32":
+			sb.WriteString("f")
+		case "string":
+			sb.WriteString("s")
+		case "vec3":
+			sb.WriteString("fff")
+		case "vec4":
+			sb.WriteString("ffff")
+		default:
+			sb.WriteString("i")
+		}
+	}
+
+	return sb.String()
+}
+
+func appendExpr(f Field, value string) string {
+	switch f.Type {
+	case "bool":
+		return "appendBool(buf, " + value + ")"
+	case "int32":
+		return "osc.AppendInt(buf, " + value + ")"
+	case "float32":
+		return "osc.AppendFloat(buf, " + value + ")"
+	case "string":
+		return "osc.AppendStringBytes(buf, " + value + ")"
+	case "vec3":
+		return "appendVec3(buf, " + value + ")"
+	case "vec4":
+		return "appendVec4(buf, " + value + ")"
+	default:
+		return "osc.AppendInt(buf, int32(" + value + "))"
+	}
+}
+
+func hasAddressVersions(m *Message) bool {
+	return len(addressVersions(m)) > 0
+}
+
+// addressVersions lists the distinct versions of all addresses, that were introduced later than the
+// message itself.
+func addressVersions(m *Message) []string {
+	var versions []string
+
+	for _, address := range m.Addresses {
+		if address.Version == "" || address.Version == m.Variants[0] {
+			continue
+		}
+
+		found := false
+
+		for _, version := range versions {
+			found = found || version == address.Version
+		}
+
+		if !found {
+			versions = append(versions, address.Version)
+		}
+	}
+
+	return versions
+}
+
+// messageReceiver is the first letter of the type name.
+func messageReceiver(name string) string {
+	return strings.ToLower(name[:1])
+}
+
+// enumReceiver is the first letter of the last word in the type name, like `s` for
+// `CalibrationState`.
+func enumReceiver(name string) string {
+	last := 0
+
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			last = i
+		}
+	}
+
+	return strings.ToLower(name[last : last+1])
+}
+
+// wrap breaks the text into lines of at most the given width.
+func wrap(text string, width int) []string {
+	var (
+		lines []string
+		line  strings.Builder
+	)
+
+	for _, word := range strings.Fields(text) {
+		if line.Len() > 0 && line.Len()+1+len(word) > width {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+
+		if line.Len() > 0 {
+			line.WriteByte(' ')
+		}
+
+		line.WriteString(word)
+	}
+
+	if line.Len() > 0 {
+		lines = append(lines, line.String())
+	}
+
+	return lines
+}
@@ -0,0 +1,291 @@
+// Package vmcgen generates the VMC message types of the vmc package from a declarative schema.
+//
+// The schema describes each message by its address, its fields and the protocol versions, in
+// which the message was extended with further arguments. From that, the message structs, parse
+// functions, encoders, String methods, the decoder storage and round-trip tests are generated.
+//
+// Supported field types are bool, int32, float32, string, vec3, vec4 and any of the enums declared
+// in the schema. Booleans and enums are transmitted as OSC integers.
+package vmcgen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+)
+
+// ErrInvalidSchema is wrapped by all errors, that describe an inconsistent schema.
+var ErrInvalidSchema = errors.New("invalid schema")
+
+// Schema is the root of the message schema.
+type Schema struct {
+	Package    string    `json:"package"`    // Package is the name of the Go package.
+	AddressDoc string    `json:"addressDoc"` // AddressDoc is the doc comment of the address constants.
+	Enums      []Enum    `json:"enums"`      // Enums are the enumerations, used by message fields.
+	Messages   []Message `json:"messages"`   // Messages are all the messages, in output order.
+}
+
+// Enum is an enumeration, that is transmitted as integer.
+type Enum struct {
+	Name      string   `json:"name"`      // Name is the Go type name.
+	Doc       string   `json:"doc"`       // Doc is the doc comment of the type.
+	ValuesDoc string   `json:"valuesDoc"` // ValuesDoc is the doc comment of the constants.
+	ErrorName string   `json:"errorName"` // ErrorName names the enum in parse errors.
+	Values    []string `json:"values"`    // Values are the names of the values, starting at 0.
+}
+
+// Message is a single VMC message.
+type Message struct {
+	Name      string    `json:"name"`      // Name is the Go type name.
+	Doc       string    `json:"doc"`       // Doc is the doc comment of the type.
+	Address   string    `json:"address"`   // Address is the OSC address, if there's only one.
+	Addresses []Address `json:"addresses"` // Addresses are the OSC addresses, if there are several.
+	Variants  []string  `json:"variants"`  // Variants are the protocol versions with changes.
+	Fields    []Field   `json:"fields"`    // Fields are the message fields, in argument order.
+}
+
+// Address is one of several addresses of a message. Each address sets the fields, that aren't part
+// of the arguments, to fixed values.
+type Address struct {
+	Const   string            `json:"const"`   // Const is the name of the address constant.
+	Address string            `json:"address"` // Address is the OSC address.
+	Version string            `json:"version"` // Version introduced the address, if it's later.
+	Set     map[string]string `json:"set"`     // Set maps field names to Go expressions.
+}
+
+// Field is a single message field.
+type Field struct {
+	Name        string `json:"name"`        // Name is the Go field name.
+	Type        string `json:"type"`        // Type is the field type.
+	Doc         string `json:"doc"`         // Doc is the field comment.
+	Since       string `json:"since"`       // Since is the variant, that added the field.
+	FromAddress bool   `json:"fromAddress"` // FromAddress fields are set by the address.
+}
+
+// Load reads and validates a schema.
+func Load(r io.Reader) (*Schema, error) {
+	var schema Schema
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, fmt.Errorf("failed decoding schema: %w", err)
+	}
+
+	if err := schema.validate(); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+func (s *Schema) enum(name string) *Enum {
+	for i := range s.Enums {
+		if s.Enums[i].Name == name {
+			return &s.Enums[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validate() error {
+	if s.Package == "" {
+		return fmt.Errorf("%w: missing package name", ErrInvalidSchema)
+	}
+
+	for i := range s.Enums {
+		if len(s.Enums[i].Values) == 0 {
+			return fmt.Errorf("%w: enum %s has no values", ErrInvalidSchema, s.Enums[i].Name)
+		}
+	}
+
+	for i := range s.Messages {
+		if err := s.validateMessage(&s.Messages[i]); err != nil {
+			return fmt.Errorf("message %s: %w", s.Messages[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) validateMessage(m *Message) error {
+	if (m.Address == "") == (len(m.Addresses) == 0) {
+		return fmt.Errorf("%w: needs either a single address or a list of addresses", ErrInvalidSchema)
+	}
+
+	if len(m.Variants) == 0 {
+		return fmt.Errorf("%w: needs at least one variant", ErrInvalidSchema)
+	}
+
+	for _, variant := range m.Variants {
+		if !isVersion(variant) {
+			return fmt.Errorf("%w: unknown version %s", ErrInvalidSchema, variant)
+		}
+	}
+
+	since := 0
+
+	for _, f := range m.Fields {
+		if !isBaseType(f.Type) && s.enum(f.Type) == nil {
+			return fmt.Errorf("%w: field %s has unknown type %s", ErrInvalidSchema, f.Name, f.Type)
+		}
+
+		if f.FromAddress {
+			if len(m.Addresses) == 0 || f.Since != "" {
+				return fmt.Errorf("%w: field %s can't be set by the address", ErrInvalidSchema, f.Name)
+			}
+
+			continue
+		}
+
+		index := 0
+
+		if f.Since != "" {
+			index = variantIndex(m, f.Since)
+			if index < 0 {
+				return fmt.Errorf("%w: field %s is added in %s, which is not a variant",
+					ErrInvalidSchema, f.Name, f.Since)
+			}
+		}
+
+		if index < since {
+			return fmt.Errorf("%w: field %s must not follow fields of a later variant",
+				ErrInvalidSchema, f.Name)
+		}
+
+		since = index
+	}
+
+	for _, variant := range m.Variants[1:] {
+		if len(optionalFields(m, variant)) == 0 {
+			return fmt.Errorf("%w: variant %s doesn't add any fields", ErrInvalidSchema, variant)
+		}
+	}
+
+	for _, address := range m.Addresses {
+		if address.Version != "" && !isVersion(address.Version) {
+			return fmt.Errorf("%w: address %s has unknown version %s",
+				ErrInvalidSchema, address.Const, address.Version)
+		}
+
+		for name := range address.Set {
+			if f := m.field(name); f == nil || !f.FromAddress {
+				return fmt.Errorf("%w: address %s sets unknown field %s", ErrInvalidSchema, address.Const, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Message) field(name string) *Field {
+	for i := range m.Fields {
+		if m.Fields[i].Name == name {
+			return &m.Fields[i]
+		}
+	}
+
+	return nil
+}
+
+// hasVersion tells whether the message carries the version of the received variant.
+func (m *Message) hasVersion() bool {
+	return len(m.Variants) > 1
+}
+
+// optional tells whether the field is only present in later variants.
+func (m *Message) optional(f *Field) bool {
+	return f.Since != "" && f.Since != m.Variants[0]
+}
+
+// wireFields returns all fields, that are transmitted as arguments.
+func (m *Message) wireFields() []Field {
+	fields := make([]Field, 0, len(m.Fields))
+
+	for _, f := range m.Fields {
+		if !f.FromAddress {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// optionalFields returns the fields, that were added in the given variant.
+func optionalFields(m *Message, variant string) []Field {
+	var fields []Field
+
+	for _, f := range m.Fields {
+		if !f.FromAddress && m.optional(&f) && f.Since == variant {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+func variantIndex(m *Message, version string) int {
+	for i, variant := range m.Variants {
+		if variant == version {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isVersion(version string) bool {
+	switch version {
+	case "V1", "V2_0", "V2_1", "V2_2", "V2_3", "V2_4", "V2_5", "V2_6", "V2_7":
+		return true
+	default:
+		return false
+	}
+}
+
+func isBaseType(typ string) bool {
+	switch typ {
+	case "bool", "int32", "float32", "string", "vec3", "vec4":
+		return true
+	default:
+		return false
+	}
+}
+
+// localName turns a field name into a local variable name, like `IPAddress` into `ipAddress`.
+func localName(name string) string {
+	runes := []rune(name)
+	upper := 0
+
+	for upper < len(runes) && runes[upper] >= 'A' && runes[upper] <= 'Z' {
+		upper++
+	}
+
+	switch {
+	case upper == 0:
+	case upper == len(runes) || upper == 1:
+		for i := 0; i < upper; i++ {
+			runes[i] += 'a' - 'A'
+		}
+	default:
+		for i := 0; i < upper-1; i++ {
+			runes[i] += 'a' - 'A'
+		}
+	}
+
+	local := string(runes)
+
+	switch {
+	case token.IsKeyword(local):
+		return local + "Value"
+	case local == "tags", local == "data", local == "msg", local == "err", local == "newData",
+		local == "version":
+		return local + "Value"
+	default:
+		return local
+	}
+}
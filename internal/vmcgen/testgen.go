@@ -0,0 +1,241 @@
+package vmcgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (g *generator) writeTests(source string) {
+	g.printf("// Code generated by vmcgen from %s; DO NOT EDIT.\n\n", source)
+	g.printf("package %s_test\n\n", g.schema.Package)
+	g.printf("import (\n\"testing\"\n\n")
+	g.printf("\"github.com/dnaka91/go-vmcparser/osc\"\n\"github.com/dnaka91/go-vmcparser/%s\"\n",
+		g.schema.Package)
+	g.printf("\"github.com/stretchr/testify/assert\"\n\"github.com/stretchr/testify/require\"\n)\n\n")
+
+	g.writeRoundTripTest()
+	g.writeTypeTagsTest()
+	g.writeTruncatedTest()
+}
+
+func (g *generator) writeRoundTripTest() {
+	g.printf("func TestMessagesRoundTrip(t *testing.T) {\ntests := []struct {\nname string\n")
+	g.printf("msg %s.Encodable\nwant %s.Message\n}{\n", g.schema.Package, g.schema.Package)
+
+	for i := range g.schema.Messages {
+		m := &g.schema.Messages[i]
+
+		switch {
+		case m.hasVersion():
+			for _, variant := range m.Variants {
+				g.printf("{name: %q, msg: %s},\n", m.Name+"/"+variant, g.sample(m, variant, variant, nil))
+			}
+
+			latest := m.Variants[len(m.Variants)-1]
+			g.printf("{name: %q, msg: %s, want: %s},\n",
+				m.Name+"/inferred",
+				g.sample(m, latest, "VersionUnknown", nil),
+				g.sample(m, latest, latest, nil),
+			)
+		case len(m.Addresses) > 0:
+			for _, address := range m.Addresses {
+				g.printf("{name: %q, msg: %s},\n",
+					m.Name+"/"+address.Const, g.sample(m, m.Variants[0], "", address.Set))
+			}
+		default:
+			g.printf("{name: %q, msg: %s},\n", m.Name, g.sample(m, m.Variants[0], "", nil))
+		}
+	}
+
+	g.printf("}\n\n")
+	g.printf("for _, tt := range tests {\ntt := tt\nt.Run(tt.name, func(t *testing.T) {\n")
+	g.printf("want := tt.want\nif want == nil {\nwant = tt.msg\n}\n\n")
+	g.printf("got, err := %s.ParseMessage(tt.msg.AppendMessage(nil))\nrequire.NoError(t, err)\n",
+		g.schema.Package)
+	g.printf("assert.Equal(t, want, got)\n})\n}\n}\n")
+}
+
+func (g *generator) writeTypeTagsTest() {
+	g.printf("\nfunc TestMessagesInvalidTypeTags(t *testing.T) {\n")
+	g.printf("tests := []struct {\naddress string\nexpected []string\n}{\n")
+
+	for i := range g.schema.Messages {
+		m := &g.schema.Messages[i]
+
+		expected := make([]string, 0, len(m.Variants))
+		for _, variant := range m.Variants {
+			expected = append(expected, fmt.Sprintf("%q", typeTags(m, variant)))
+		}
+
+		list := "[]string{" + strings.Join(expected, ", ") + "}"
+		if len(m.Variants) == 1 && typeTags(m, m.Variants[0]) == "" {
+			list = "nil"
+		}
+
+		for _, address := range addressConsts(m) {
+			g.printf("{%s.%s, %s},\n", g.schema.Package, address, list)
+		}
+	}
+
+	g.printf("}\n\n")
+	g.printf("for _, tt := range tests {\n")
+	g.printf("_, err := %s.ParseMessage(osc.AppendMessage(nil, tt.address, []byte(\"N\"), nil))\n\n",
+		g.schema.Package)
+	g.printf("var tagsErr %s.InvalidTypeTagsError\nrequire.ErrorAs(t, err, &tagsErr, tt.address)\n",
+		g.schema.Package)
+	g.printf("assert.Equal(t, tt.expected, tagsErr.Expected, tt.address)\n}\n}\n")
+}
+
+func (g *generator) writeTruncatedTest() {
+	g.printf("\nfunc TestMessagesTruncated(t *testing.T) {\n")
+	g.printf("tests := []struct {\nname string\nmsg %s.Encodable\n}{\n", g.schema.Package)
+
+	for i := range g.schema.Messages {
+		m := &g.schema.Messages[i]
+
+		if len(m.wireFields()) == 0 {
+			continue
+		}
+
+		latest := m.Variants[len(m.Variants)-1]
+		version := ""
+
+		if m.hasVersion() {
+			version = latest
+		}
+
+		var set map[string]string
+		if len(m.Addresses) > 0 {
+			set = m.Addresses[0].Set
+		}
+
+		g.printf("{name: %q, msg: %s},\n", m.Name, g.sample(m, latest, version, set))
+	}
+
+	g.printf("}\n\n")
+	g.printf("for _, tt := range tests {\ntt := tt\nt.Run(tt.name, func(t *testing.T) {\n")
+	g.printf("raw := tt.msg.AppendMessage(nil)\n\n")
+	g.printf("_, err := %s.ParseMessage(raw[:len(raw)-4])\nassert.Error(t, err)\n})\n}\n}\n",
+		g.schema.Package)
+}
+
+// sample creates a message literal with distinct values for every field. Fields that were added
+// after the variant are left empty. The version is the value of the Version field, if the message
+// has one.
+func (g *generator) sample(m *Message, variant, version string, set map[string]string) string {
+	var sb strings.Builder
+
+	pkg := g.schema.Package
+	limit := variantIndex(m, variant)
+	counter := 1
+
+	fmt.Fprintf(&sb, "&%s.%s{", pkg, m.Name)
+
+	for i := range m.Fields {
+		f := &m.Fields[i]
+
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		switch {
+		case f.FromAddress:
+			fmt.Fprintf(&sb, "%s: %s", f.Name, g.qualify(f, set[f.Name]))
+		case m.optional(f) && variantIndex(m, f.Since) > limit:
+			fmt.Fprintf(&sb, "%s: %s.None[%s]()", f.Name, pkg, g.qualifiedType(f))
+		case m.optional(f):
+			fmt.Fprintf(&sb, "%s: %s.Some(%s)", f.Name, pkg, g.sampleValue(f, &counter, true))
+		default:
+			fmt.Fprintf(&sb, "%s: %s", f.Name, g.sampleValue(f, &counter, false))
+		}
+	}
+
+	if version != "" {
+		if len(m.Fields) > 0 {
+			sb.WriteString(", ")
+		}
+
+		fmt.Fprintf(&sb, "Version: %s.Protocol%s", pkg, version)
+	}
+
+	sb.WriteString("}")
+
+	return sb.String()
+}
+
+func (g *generator) sampleValue(f *Field, counter *int, typed bool) string {
+	n := *counter
+	pkg := g.schema.Package
+
+	switch f.Type {
+	case "bool":
+		*counter++
+
+		return "true"
+	case "int32":
+		*counter++
+
+		if typed {
+			return fmt.Sprintf("int32(%d)", n)
+		}
+
+		return fmt.Sprintf("%d", n)
+	case "float32":
+		*counter++
+
+		if typed {
+			return fmt.Sprintf("float32(%d.5)", n)
+		}
+
+		return fmt.Sprintf("%d.5", n)
+	case "string":
+		*counter++
+
+		return fmt.Sprintf("[]byte(%q)", strings.ToLower(f.Name))
+	case "vec3":
+		*counter += 3
+
+		return fmt.Sprintf("%s.Vec3{X: %d.5, Y: %d.5, Z: %d.5}", pkg, n, n+1, n+2)
+	case "vec4":
+		*counter += 4
+
+		return fmt.Sprintf("%s.Vec4{X: %d.5, Y: %d.5, Z: %d.5, W: %d.5}", pkg, n, n+1, n+2, n+3)
+	default:
+		*counter++
+		enum := g.schema.enum(f.Type)
+
+		return fmt.Sprintf("%s.%s%s", pkg, enum.Name, enum.Values[len(enum.Values)-1])
+	}
+}
+
+// qualify adds the package name to a value expression, that is given in the schema, unless it's a
+// boolean.
+func (g *generator) qualify(f *Field, value string) string {
+	if f.Type == "bool" {
+		return value
+	}
+
+	return g.schema.Package + "." + value
+}
+
+func (g *generator) qualifiedType(f *Field) string {
+	switch f.Type {
+	case "bool", "int32", "float32", "string":
+		return baseType(f)
+	default:
+		return g.schema.Package + "." + baseType(f)
+	}
+}
+
+func addressConsts(m *Message) []string {
+	if m.Address != "" {
+		return []string{"Address" + m.Name}
+	}
+
+	consts := make([]string, 0, len(m.Addresses))
+	for _, address := range m.Addresses {
+		consts = append(consts, address.Const)
+	}
+
+	return consts
+}
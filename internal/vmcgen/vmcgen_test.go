@@ -0,0 +1,67 @@
+package vmcgen_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/internal/vmcgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateUpToDate(t *testing.T) {
+	file, err := os.Open("../../vmc/marionette.json")
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	schema, err := vmcgen.Load(file)
+	require.NoError(t, err)
+
+	files, err := vmcgen.Generate(schema, "marionette.json")
+	require.NoError(t, err)
+
+	code, err := os.ReadFile("../../vmc/marionette_gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(code), string(files.Code), "messages are outdated, run `go generate ./...`")
+
+	tests, err := os.ReadFile("../../vmc/marionette_gen_test.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(tests), string(files.Tests), "tests are outdated, run `go generate ./...`")
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{"no address", `{"name": "A", "variants": ["V1"]}`},
+		{"both addresses", `{"name": "A", "address": "/a", "addresses": [{"const": "B", "address": "/b"}], "variants": ["V1"]}`},
+		{"no variants", `{"name": "A", "address": "/a"}`},
+		{"unknown version", `{"name": "A", "address": "/a", "variants": ["V3"]}`},
+		{"unknown type", `{"name": "A", "address": "/a", "variants": ["V1"], "fields": [{"name": "B", "type": "int64"}]}`},
+		{"unknown since", `{"name": "A", "address": "/a", "variants": ["V1"], "fields": [{"name": "B", "type": "bool", "since": "V2_0"}]}`},
+		{"empty variant", `{"name": "A", "address": "/a", "variants": ["V1", "V2_0"], "fields": [{"name": "B", "type": "bool"}]}`},
+		{"from address", `{"name": "A", "address": "/a", "variants": ["V1"], "fields": [{"name": "B", "type": "bool", "fromAddress": true}]}`},
+		{
+			"field order",
+			`{"name": "A", "address": "/a", "variants": ["V1", "V2_0"], "fields": [
+				{"name": "B", "type": "bool", "since": "V2_0"},
+				{"name": "C", "type": "bool"}
+			]}`,
+		},
+		{
+			"unknown set",
+			`{"name": "A", "addresses": [{"const": "B", "address": "/b", "set": {"C": "true"}}], "variants": ["V1"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := vmcgen.Load(strings.NewReader(`{"package": "vmc", "messages": [` + tt.message + `]}`))
+			assert.ErrorIs(t, err, vmcgen.ErrInvalidSchema)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Package merge combines the avatar frames of several VMC senders into a single stream.
+//
+// A common setup is face tracking in one application and body tracking in another. The Merger
+// collects the frames of both, and composes a merged frame by rules, like taking the bones from the
+// body tracker and the blend shapes from the face tracker:
+//
+//	merger := merge.New(merge.Config{
+//		Root:        merge.Rule{Sources: []string{"body"}},
+//		Bones:       merge.Rule{Sources: []string{"body"}},
+//		BlendShapes: merge.Rule{Sources: []string{"face"}},
+//		Timeout:     time.Second,
+//	})
+//
+// Sources are identified by arbitrary names, like the sender address.
+package merge
+
+import (
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+// Rule selects the source of a part of the merged frame.
+type Rule struct {
+	// Sources are the candidate sources, in order of priority. Only sources that completed a frame
+	// within their timeout are considered.
+	Sources []string
+	// Latest picks the candidate, that completed a frame most recently, instead of the first one
+	// by priority.
+	Latest bool
+}
+
+// Config defines how frames are merged.
+type Config struct {
+	Root        Rule // Root selects the source of the root transform and relative time.
+	Bones       Rule // Bones selects the source of all bones.
+	BlendShapes Rule // BlendShapes selects the source of all blend shapes.
+	// BoneOverrides selects the source of individual bones, keyed by bone name. If none of the
+	// override sources is active, the bone is taken from the Bones source.
+	BoneOverrides map[string]Rule
+	// BlendShapeOverrides selects the source of individual blend shapes, keyed by name. If none of
+	// the override sources is active, the value is taken from the BlendShapes source.
+	BlendShapeOverrides map[string]Rule
+	// Timeout is the time after the last completed frame, until a source is considered inactive.
+	// If zero, sources never time out.
+	Timeout time.Duration
+	// Timeouts overrides the timeout of individual sources.
+	Timeouts map[string]time.Duration
+	// Triggers are the sources, that emit a merged frame when they complete a frame. If empty,
+	// every completed frame of any source emits a merged frame.
+	Triggers []string
+}
+
+// Merger combines the frames of several sources. Parts of the merged frame, that have no active
+// source, keep their last value. It must not be used concurrently.
+type Merger struct {
+	config  Config
+	sources map[string]*source
+	output  vmc.Frame
+}
+
+type source struct {
+	builder vmc.FrameBuilder
+	last    time.Time
+	frames  int
+}
+
+// New creates a new merger with the given configuration.
+func New(config Config) *Merger {
+	return &Merger{
+		config:  config,
+		sources: make(map[string]*source),
+		output: vmc.Frame{
+			Time:        0,
+			RootName:    "",
+			Root:        vmc.Pose{Position: vmc.Vec3{}, Quaternion: vmc.Vec4{X: 0, Y: 0, Z: 0, W: 1}},
+			Bones:       make(map[string]vmc.Pose),
+			BlendShapes: make(map[string]float32),
+		},
+	}
+}
+
+// Add collects a message of the named source, received at the given time. Once the source
+// completes a frame with a BlendShapeProxyApply message, and is one of the triggers, the merged
+// frame is returned.
+//
+// The returned frame is owned by the merger and only valid until the next call to Add.
+func (m *Merger) Add(name string, msg vmc.Message, at time.Time) (*vmc.Frame, bool) {
+	src, ok := m.sources[name]
+	if !ok {
+		src = &source{builder: vmc.FrameBuilder{}, last: time.Time{}, frames: 0}
+		m.sources[name] = src
+	}
+
+	if !src.builder.Add(msg) {
+		return nil, false
+	}
+
+	src.last = at
+	src.frames++
+
+	if !m.triggers(name) {
+		return nil, false
+	}
+
+	m.compose(at)
+
+	return &m.output, true
+}
+
+// Active tells whether the named source completed a frame within its timeout.
+func (m *Merger) Active(name string, now time.Time) bool {
+	src, ok := m.sources[name]
+	if !ok || src.frames == 0 {
+		return false
+	}
+
+	timeout, ok := m.config.Timeouts[name]
+	if !ok {
+		timeout = m.config.Timeout
+	}
+
+	return timeout == 0 || now.Sub(src.last) <= timeout
+}
+
+// Remove forgets all state of the named source.
+func (m *Merger) Remove(name string) {
+	delete(m.sources, name)
+}
+
+func (m *Merger) triggers(name string) bool {
+	if len(m.config.Triggers) == 0 {
+		return true
+	}
+
+	for _, trigger := range m.config.Triggers {
+		if trigger == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pick selects the frame of the source, that matches the rule best.
+func (m *Merger) pick(rule *Rule, now time.Time) *vmc.Frame {
+	var picked *source
+
+	for _, name := range rule.Sources {
+		if !m.Active(name, now) {
+			continue
+		}
+
+		src := m.sources[name]
+
+		if !rule.Latest {
+			return src.builder.Frame()
+		}
+
+		if picked == nil || src.last.After(picked.last) {
+			picked = src
+		}
+	}
+
+	if picked == nil {
+		return nil
+	}
+
+	return picked.builder.Frame()
+}
+
+func (m *Merger) compose(now time.Time) {
+	if frame := m.pick(&m.config.Root, now); frame != nil {
+		m.output.Time = frame.Time
+		m.output.RootName = frame.RootName
+		m.output.Root = frame.Root
+	}
+
+	if frame := m.pick(&m.config.Bones, now); frame != nil {
+		for name := range m.output.Bones {
+			delete(m.output.Bones, name)
+		}
+
+		for name, pose := range frame.Bones {
+			m.output.Bones[name] = pose
+		}
+	}
+
+	for name, rule := range m.config.BoneOverrides {
+		rule := rule
+		if frame := m.pick(&rule, now); frame != nil {
+			if pose, ok := frame.Bones[name]; ok {
+				m.output.Bones[name] = pose
+			}
+		}
+	}
+
+	if frame := m.pick(&m.config.BlendShapes, now); frame != nil {
+		for name := range m.output.BlendShapes {
+			delete(m.output.BlendShapes, name)
+		}
+
+		for name, value := range frame.BlendShapes {
+			m.output.BlendShapes[name] = value
+		}
+	}
+
+	for name, rule := range m.config.BlendShapeOverrides {
+		rule := rule
+		if frame := m.pick(&rule, now); frame != nil {
+			if value, ok := frame.BlendShapes[name]; ok {
+				m.output.BlendShapes[name] = value
+			}
+		}
+	}
+}
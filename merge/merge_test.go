@@ -0,0 +1,124 @@
+package merge_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/merge"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// send adds the messages and a BlendShapeProxyApply, returning the result of the last one.
+func send(m *merge.Merger, name string, at time.Time, msgs ...vmc.Message) (*vmc.Frame, bool) {
+	for _, msg := range msgs {
+		m.Add(name, msg, at)
+	}
+
+	return m.Add(name, &vmc.BlendShapeProxyApply{}, at)
+}
+
+func root(x float32) *vmc.RootTransform {
+	return &vmc.RootTransform{Name: []byte("root"), Position: vmc.Vec3{X: x}, Quaternion: vmc.Vec4{W: 1}}
+}
+
+func bone(name string, y float32) *vmc.BoneTransform {
+	return &vmc.BoneTransform{Name: []byte(name), Position: vmc.Vec3{Y: y}, Quaternion: vmc.Vec4{W: 1}}
+}
+
+func blend(name string, value float32) *vmc.BlendShapeProxyValue {
+	return &vmc.BlendShapeProxyValue{Name: []byte(name), Value: value}
+}
+
+func TestMergeParts(t *testing.T) {
+	m := merge.New(merge.Config{
+		Root:        merge.Rule{Sources: []string{"body", "face"}, Latest: true},
+		Bones:       merge.Rule{Sources: []string{"body"}},
+		BlendShapes: merge.Rule{Sources: []string{"face"}},
+	})
+	start := time.Now()
+
+	frame, ok := send(m, "body", start, root(1), bone("Hips", 1), blend("A", 0.1))
+	require.True(t, ok)
+	assert.Equal(t, float32(1), frame.Root.Position.X)
+	assert.Equal(t, "root", frame.RootName)
+	assert.Equal(t, map[string]vmc.Pose{"Hips": {Position: vmc.Vec3{Y: 1}, Quaternion: vmc.Vec4{W: 1}}}, frame.Bones)
+	assert.Empty(t, frame.BlendShapes, "blend shapes only come from the face source")
+
+	frame, ok = send(m, "face", start.Add(time.Millisecond), root(2), bone("Head", 2), blend("A", 0.5))
+	require.True(t, ok)
+	assert.Equal(t, float32(2), frame.Root.Position.X, "face source is the most recent")
+	assert.NotContains(t, frame.Bones, "Head")
+	assert.Equal(t, map[string]float32{"A": 0.5}, frame.BlendShapes)
+}
+
+func TestMergeOverrides(t *testing.T) {
+	m := merge.New(merge.Config{
+		Bones:         merge.Rule{Sources: []string{"body"}},
+		BoneOverrides: map[string]merge.Rule{"Head": {Sources: []string{"face"}}},
+		BlendShapes:   merge.Rule{Sources: []string{"face"}},
+		BlendShapeOverrides: map[string]merge.Rule{
+			"Blink": {Sources: []string{"body"}},
+		},
+		Timeout: time.Second,
+	})
+	start := time.Now()
+
+	send(m, "face", start, bone("Head", 5), blend("A", 0.5), blend("Blink", 0))
+
+	frame, ok := send(m, "body", start, bone("Head", 1), bone("Hips", 1), blend("Blink", 1))
+	require.True(t, ok)
+	assert.Equal(t, float32(5), frame.Bones["Head"].Position.Y)
+	assert.Equal(t, float32(1), frame.Bones["Hips"].Position.Y)
+	assert.Equal(t, map[string]float32{"A": 0.5, "Blink": 1}, frame.BlendShapes)
+
+	// Once the face source times out, the head falls back to the body source, while the blend
+	// shapes keep their last value.
+	frame, ok = send(m, "body", start.Add(2*time.Second), bone("Head", 2), blend("Blink", 0.5))
+	require.True(t, ok)
+	assert.Equal(t, float32(2), frame.Bones["Head"].Position.Y)
+	assert.Equal(t, map[string]float32{"A": 0.5, "Blink": 0.5}, frame.BlendShapes)
+}
+
+func TestMergeTimeouts(t *testing.T) {
+	m := merge.New(merge.Config{
+		Root:     merge.Rule{Sources: []string{"a", "b"}},
+		Timeout:  time.Second,
+		Timeouts: map[string]time.Duration{"b": 0},
+	})
+	start := time.Now()
+
+	assert.False(t, m.Active("a", start))
+
+	send(m, "a", start, root(1))
+	send(m, "b", start, root(2))
+	assert.True(t, m.Active("a", start.Add(time.Second)))
+	assert.False(t, m.Active("a", start.Add(2*time.Second)))
+	assert.True(t, m.Active("b", start.Add(time.Hour)), "b never times out")
+
+	frame, ok := send(m, "b", start.Add(2*time.Second), root(3))
+	require.True(t, ok)
+	assert.Equal(t, float32(3), frame.Root.Position.X, "a has priority but timed out")
+
+	m.Remove("b")
+	assert.False(t, m.Active("b", start))
+}
+
+func TestMergeTriggers(t *testing.T) {
+	m := merge.New(merge.Config{
+		Root:     merge.Rule{Sources: []string{"a", "b"}},
+		Triggers: []string{"b"},
+	})
+	start := time.Now()
+
+	_, ok := send(m, "a", start, root(1))
+	assert.False(t, ok)
+
+	_, ok = m.Add("b", root(2), start)
+	assert.False(t, ok, "frame isn't complete yet")
+
+	frame, ok := m.Add("b", &vmc.BlendShapeProxyApply{}, start)
+	require.True(t, ok)
+	assert.Equal(t, float32(1), frame.Root.Position.X)
+}
@@ -0,0 +1,208 @@
+// Package mirror flips avatars left to right, by mirroring parsed VMC messages across the X axis.
+//
+// Mirroring covers three aspects:
+//
+//   - Positions and rotations of all transforms are reflected on the YZ plane.
+//   - Bones with a side, like LeftHand and RightHand, swap their names.
+//   - Blend shapes with a side swap their names as well. This covers VRM 0.x names like Blink_L or
+//     BlinkL, VRM 1.0 names like blinkLeft and ARKit names like eyeBlinkLeft.
+package mirror
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+// Stage mirrors messages in place. It's used between parsing and re-encoding of messages.
+//
+// Swapped names are written into a buffer owned by the stage, and therefore only valid until the
+// next call to Process. The zero value is ready to use. A stage must not be used concurrently.
+type Stage struct {
+	// Pairs are additional names, that are swapped with each other. They apply to bones and blend
+	// shapes, and are checked before the built-in rules. Each pair only needs to be listed in one
+	// direction.
+	//
+	// Overlapping pairs are accepted greedily, in byte order of their keys. A pair is skipped if
+	// either of its names is already taken by an accepted pair. Pairs must not be changed after the
+	// first call to Process.
+	Pairs map[string]string
+
+	name  []byte
+	swaps map[string]string
+}
+
+// Process mirrors the message in place. Messages without any transforms or names are left
+// untouched.
+func (s *Stage) Process(msg vmc.Message) {
+	switch m := msg.(type) {
+	case *vmc.RootTransform:
+		m.Position, m.Quaternion = Position(m.Position), Rotation(m.Quaternion)
+
+		if offset, ok := m.Offset.Get(); ok {
+			m.Offset = vmc.Some(Position(offset))
+		}
+	case *vmc.BoneTransform:
+		m.Name = s.swap(m.Name, boneSide)
+		m.Position, m.Quaternion = Position(m.Position), Rotation(m.Quaternion)
+	case *vmc.BlendShapeProxyValue:
+		m.Name = s.swap(m.Name, blendShapeSide)
+	case *vmc.CameraTransform:
+		m.Position, m.Quaternion = Position(m.Position), Rotation(m.Quaternion)
+	case *vmc.ControllerInput:
+		m.IsLeft = !m.IsLeft
+		m.Axis.X = -m.Axis.X
+	case *vmc.DeviceTransform:
+		m.Position, m.Quaternion = Position(m.Position), Rotation(m.Quaternion)
+	case *vmc.DirectionalLight:
+		m.Position, m.Quaternion = Position(m.Position), Rotation(m.Quaternion)
+	case *vmc.EyeTarget:
+		m.Position = Position(m.Position)
+	}
+}
+
+// Position mirrors a position across the X axis.
+func Position(position vmc.Vec3) vmc.Vec3 {
+	return vmc.Vec3{X: -position.X, Y: position.Y, Z: position.Z}
+}
+
+// Rotation mirrors a rotation quaternion across the X axis. The rotation axis is reflected and
+// the rotation direction reversed, which leaves the X component as is.
+func Rotation(rotation vmc.Vec4) vmc.Vec4 {
+	return vmc.Vec4{X: rotation.X, Y: -rotation.Y, Z: -rotation.Z, W: rotation.W}
+}
+
+// BoneName returns the name of the bone on the opposite side, or the name itself if the bone has
+// no side.
+func BoneName(name string) string {
+	return swapName(name, boneSide)
+}
+
+// BlendShapeName returns the name of the blend shape on the opposite side, or the name itself if
+// the blend shape has no side.
+func BlendShapeName(name string) string {
+	return swapName(name, blendShapeSide)
+}
+
+func swapName(name string, sides sideFunc) string {
+	index, found := sides([]byte(name))
+	if !found.valid() {
+		return name
+	}
+
+	return string(replaceSide(nil, []byte(name), index, found))
+}
+
+func (s *Stage) swap(name []byte, sides sideFunc) []byte {
+	if s.swaps == nil {
+		s.swaps = buildSwaps(s.Pairs)
+	}
+
+	if other, ok := s.swaps[string(name)]; ok {
+		s.name = append(s.name[:0], other...)
+
+		return s.name
+	}
+
+	index, found := sides(name)
+	if !found.valid() {
+		return name
+	}
+
+	s.name = replaceSide(s.name[:0], name, index, found)
+
+	return s.name
+}
+
+// buildSwaps turns the pairs into a lookup table for both directions. Pairs are added in order of
+// their keys, and names that are already taken by a previous pair are skipped, so that overlapping
+// pairs give the same result regardless of the map iteration order.
+func buildSwaps(pairs map[string]string) map[string]string {
+	keys := make([]string, 0, len(pairs))
+	for a := range pairs {
+		keys = append(keys, a)
+	}
+
+	sort.Strings(keys)
+
+	swaps := make(map[string]string, 2*len(pairs))
+
+	for _, a := range keys {
+		b := pairs[a]
+
+		_, takenA := swaps[a]
+		_, takenB := swaps[b]
+
+		if takenA || takenB {
+			continue
+		}
+
+		swaps[a] = b
+		swaps[b] = a
+	}
+
+	return swaps
+}
+
+// side is a marker for left or right in a name, and its replacement.
+type side struct {
+	marker      string
+	replacement string
+}
+
+func (s side) valid() bool {
+	return s.marker != ""
+}
+
+// sideFunc finds the side marker in a name, and returns its position.
+type sideFunc func(name []byte) (int, side)
+
+// replaceSide appends the name with the side marker at the index replaced to dst.
+func replaceSide(dst, name []byte, index int, found side) []byte {
+	dst = append(dst, name[:index]...)
+	dst = append(dst, found.replacement...)
+
+	return append(dst, name[index+len(found.marker):]...)
+}
+
+// boneSide finds the side of Unity's humanoid bones, which start with Left or Right.
+func boneSide(name []byte) (int, side) {
+	switch {
+	case bytes.HasPrefix(name, []byte("Left")):
+		return 0, side{marker: "Left", replacement: "Right"}
+	case bytes.HasPrefix(name, []byte("Right")):
+		return 0, side{marker: "Right", replacement: "Left"}
+	default:
+		return 0, side{marker: "", replacement: ""}
+	}
+}
+
+// blendShapeSide finds the side of blend shapes, which end with a side marker in any of the common
+// naming schemes.
+func blendShapeSide(name []byte) (int, side) {
+	for _, candidate := range [...]side{
+		{marker: "Left", replacement: "Right"},
+		{marker: "Right", replacement: "Left"},
+		{marker: "_L", replacement: "_R"},
+		{marker: "_R", replacement: "_L"},
+		{marker: "L", replacement: "R"},
+		{marker: "R", replacement: "L"},
+	} {
+		if !bytes.HasSuffix(name, []byte(candidate.marker)) {
+			continue
+		}
+
+		index := len(name) - len(candidate.marker)
+
+		// Single letter markers must follow a lower case letter, like in BlinkL, so that names
+		// which merely end in a capital letter aren't affected.
+		if len(candidate.marker) == 1 && (index == 0 || name[index-1] < 'a' || name[index-1] > 'z') {
+			continue
+		}
+
+		return index, candidate
+	}
+
+	return 0, side{marker: "", replacement: ""}
+}
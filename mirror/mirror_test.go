@@ -0,0 +1,139 @@
+package mirror_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/mirror"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNames(t *testing.T) {
+	bones := map[string]string{
+		"LeftHand":           "RightHand",
+		"RightUpperLeg":      "LeftUpperLeg",
+		"LeftThumbProximal":  "RightThumbProximal",
+		"Hips":               "Hips",
+		"UpperChestLeftover": "UpperChestLeftover",
+	}
+
+	for name, want := range bones {
+		assert.Equal(t, want, mirror.BoneName(name), name)
+	}
+
+	blendShapes := map[string]string{
+		"Blink_L":       "Blink_R",
+		"BlinkR":        "BlinkL",
+		"blinkLeft":     "blinkRight",
+		"lookRight":     "lookLeft",
+		"eyeBlinkLeft":  "eyeBlinkRight",
+		"mouthLeft":     "mouthRight",
+		"Blink":         "Blink",
+		"A":             "A",
+		"L":             "L",
+		"JOY_CUSTOM_R2": "JOY_CUSTOM_R2",
+	}
+
+	for name, want := range blendShapes {
+		assert.Equal(t, want, mirror.BlendShapeName(name), name)
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	// Mirroring twice must restore the original values.
+	rotation := vmc.Vec4{X: 0.1, Y: 0.2, Z: 0.3, W: 0.9}
+	assert.Equal(t, rotation, mirror.Rotation(mirror.Rotation(rotation)))
+	assert.Equal(t, vmc.Vec3{X: -1, Y: 2, Z: 3}, mirror.Position(vmc.Vec3{X: 1, Y: 2, Z: 3}))
+}
+
+func TestProcess(t *testing.T) {
+	var stage mirror.Stage
+
+	bone := &vmc.BoneTransform{
+		Name:       []byte("LeftHand"),
+		Position:   vmc.Vec3{X: 1, Y: 2, Z: 3},
+		Quaternion: vmc.Vec4{X: 0.1, Y: 0.2, Z: 0.3, W: 0.9},
+	}
+	stage.Process(bone)
+	assert.Equal(t, &vmc.BoneTransform{
+		Name:       []byte("RightHand"),
+		Position:   vmc.Vec3{X: -1, Y: 2, Z: 3},
+		Quaternion: vmc.Vec4{X: 0.1, Y: -0.2, Z: -0.3, W: 0.9},
+	}, bone)
+
+	root := &vmc.RootTransform{
+		Name:       []byte("root"),
+		Position:   vmc.Vec3{X: 1},
+		Quaternion: vmc.Vec4{W: 1},
+		Scale:      vmc.Some(vmc.Vec3{X: 1, Y: 1, Z: 1}),
+		Offset:     vmc.Some(vmc.Vec3{X: 2}),
+		Version:    vmc.ProtocolV2_1,
+	}
+	stage.Process(root)
+	assert.Equal(t, vmc.Vec3{X: -1}, root.Position)
+	assert.Equal(t, vmc.Some(vmc.Vec3{X: 1, Y: 1, Z: 1}), root.Scale)
+	assert.Equal(t, vmc.Some(vmc.Vec3{X: -2}), root.Offset)
+
+	blend := &vmc.BlendShapeProxyValue{Name: []byte("Blink_L"), Value: 1}
+	stage.Process(blend)
+	assert.Equal(t, &vmc.BlendShapeProxyValue{Name: []byte("Blink_R"), Value: 1}, blend)
+
+	controller := &vmc.ControllerInput{Name: []byte("Trigger"), IsLeft: true, Axis: vmc.Vec3{X: 0.5, Y: 1}}
+	stage.Process(controller)
+	assert.False(t, controller.IsLeft)
+	assert.Equal(t, vmc.Vec3{X: -0.5, Y: 1}, controller.Axis)
+
+	device := &vmc.DeviceTransform{Serial: []byte("LHR-1"), Position: vmc.Vec3{X: 1}, Quaternion: vmc.Vec4{Y: 1}}
+	stage.Process(device)
+	assert.Equal(t, vmc.Vec3{X: -1}, device.Position)
+	assert.Equal(t, vmc.Vec4{Y: -1}, device.Quaternion)
+}
+
+func TestPairs(t *testing.T) {
+	stage := mirror.Stage{Pairs: map[string]string{"Wink": "Smirk"}}
+
+	blend := &vmc.BlendShapeProxyValue{Name: []byte("Smirk"), Value: 1}
+	stage.Process(blend)
+	assert.Equal(t, "Wink", string(blend.Name))
+
+	bone := &vmc.BoneTransform{Name: []byte("Wink")}
+	stage.Process(bone)
+	assert.Equal(t, "Smirk", string(bone.Name))
+}
+
+func TestPairsOverlapping(t *testing.T) {
+	tests := []struct {
+		name  string
+		pairs map[string]string
+		want  map[string]string
+	}{
+		{
+			// Fun is taken by Angry, so the pair with Joy is skipped.
+			name:  "shared",
+			pairs: map[string]string{"Fun": "Joy", "Joy": "Sorrow", "Angry": "Fun"},
+			want:  map[string]string{"Angry": "Fun", "Fun": "Angry", "Joy": "Sorrow", "Sorrow": "Joy"},
+		},
+		{
+			// B is taken by A, so B:C is skipped, while C is still free for C:D.
+			name:  "chain",
+			pairs: map[string]string{"A": "B", "B": "C", "C": "D"},
+			want:  map[string]string{"A": "B", "B": "A", "C": "D", "D": "C"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeated, as map iteration order must not change the result.
+			for i := 0; i < 20; i++ {
+				stage := mirror.Stage{Pairs: tt.pairs}
+
+				for name, want := range tt.want {
+					blend := &vmc.BlendShapeProxyValue{Name: []byte(name), Value: 1}
+					stage.Process(blend)
+					assert.Equal(t, want, string(blend.Name), name)
+				}
+			}
+		})
+	}
+}
@@ -13,7 +13,9 @@ var (
 	ErrIntTooShort             = errors.New("content is too short for an int")
 	ErrFloatTooShort           = errors.New("content is too short for a float")
 	ErrStringMissingTerminator = errors.New("string missing 0 terminator")
+	ErrStringMissingPadding    = errors.New("string missing padding")
 	ErrBlobTooShort            = errors.New("content is too short for a blob")
+	ErrBlobMissingPadding      = errors.New("blob missing padding")
 	ErrInt64TooShort           = errors.New("content is too short for an int64")
 	ErrTimeTagTooShort         = errors.New("content is too short for an time tag")
 	ErrDoubleTooShort          = errors.New("content is too short for a double")
@@ -61,7 +63,12 @@ func ReadString(buf []byte) ([]byte, []byte, error) {
 
 	value := buf[:pos]
 
-	return value, buf[len(value)+pad(len(value)):], nil
+	end := len(value) + pad(len(value))
+	if end > len(buf) {
+		return nil, nil, ErrStringMissingPadding
+	}
+
+	return value, buf[end:], nil
 }
 
 func readLength(buf []byte) (int, []byte, error) {
@@ -88,15 +95,28 @@ func readBlob(buf []byte) ([]byte, []byte, error) {
 		return nil, nil, ErrBlobTooShort
 	}
 
-	return buf[:length], buf[length+pad(length):], nil
+	end := length + blobPad(length)
+	if end > len(buf) {
+		return nil, nil, ErrBlobMissingPadding
+	}
+
+	return buf[:length], buf[end:], nil
 }
 
-func pad(length int) int {
-	const padSize = 4
+const padSize = 4
 
+// pad calculates the padding for a string of the given length. Strings always need at least one
+// 0 byte as terminator, so the padding is between 1 and 4 bytes.
+func pad(length int) int {
 	return padSize - length%padSize
 }
 
+// blobPad calculates the padding for a blob of the given length. Unlike strings, blobs don't have
+// a terminator, so the padding is between 0 and 3 bytes.
+func blobPad(length int) int {
+	return (padSize - length%padSize) % padSize
+}
+
 func readInt64(buf []byte) (int64, []byte, error) {
 	if len(buf) < lenInt64 {
 		return 0, nil, ErrInt64TooShort
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestParseInt(t *testing.T) {
@@ -187,3 +188,26 @@ func TestParseInfinitum(t *testing.T) {
 		},
 	})
 }
+
+func TestParseBlobAligned(t *testing.T) {
+	input := []byte("/\x00\x00\x00,bi\x00\x00\x00\x00\x04\x01\x02\x03\x04\x00\x00\x00\x05")
+
+	assertPacket(t, input, &osc.Packet{
+		Message: &osc.Message{
+			Address:   []byte("/"),
+			TypeTags:  []byte("bi"),
+			Arguments: []interface{}{[]byte{1, 2, 3, 4}, int32(5)},
+			Raw:       input,
+		},
+	})
+}
+
+func TestParseBlobMissingPadding(t *testing.T) {
+	_, _, err := osc.ReadPacket([]byte("/\x00\x00\x00,b\x00\x00\x00\x00\x00\x03\x01\x02\x03"))
+	assert.ErrorIs(t, err, osc.ErrBlobMissingPadding)
+}
+
+func TestParseStringMissingPadding(t *testing.T) {
+	_, _, err := osc.ReadString([]byte("ab\x00"))
+	assert.ErrorIs(t, err, osc.ErrStringMissingPadding)
+}
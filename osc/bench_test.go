@@ -0,0 +1,151 @@
+package osc_test
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+)
+
+func appendString(buf []byte, value string) []byte {
+	buf = append(buf, value...)
+
+	return append(buf, make([]byte, 4-len(value)%4)...)
+}
+
+func appendUint32(buf []byte, value uint32) []byte {
+	var raw [4]byte
+
+	binary.BigEndian.PutUint32(raw[:], value)
+
+	return append(buf, raw[:]...)
+}
+
+func appendFloats(buf []byte, values ...float32) []byte {
+	for _, value := range values {
+		buf = appendUint32(buf, math.Float32bits(value))
+	}
+
+	return buf
+}
+
+func appendElement(buf, message []byte) []byte {
+	buf = appendUint32(buf, uint32(len(message)))
+
+	return append(buf, message...)
+}
+
+// fullBodyBundle creates a bundle, similar to what VMC senders transmit for every frame. It
+// contains the relative time, the root transform, all humanoid bones and the VRM 0.x blend shapes.
+func fullBodyBundle() []byte {
+	bones := []string{
+		"Hips", "LeftUpperLeg", "RightUpperLeg", "LeftLowerLeg", "RightLowerLeg", "LeftFoot",
+		"RightFoot", "Spine", "Chest", "UpperChest", "Neck", "Head", "LeftShoulder",
+		"RightShoulder", "LeftUpperArm", "RightUpperArm", "LeftLowerArm", "RightLowerArm",
+		"LeftHand", "RightHand", "LeftToes", "RightToes", "LeftEye", "RightEye", "Jaw",
+	}
+
+	for _, side := range []string{"Left", "Right"} {
+		for _, finger := range []string{"Thumb", "Index", "Middle", "Ring", "Little"} {
+			for _, joint := range []string{"Proximal", "Intermediate", "Distal"} {
+				bones = append(bones, side+finger+joint)
+			}
+		}
+	}
+
+	blendShapes := []string{
+		"Neutral", "A", "I", "U", "E", "O", "Blink", "Joy", "Angry", "Sorrow", "Fun",
+		"LookUp", "LookDown", "LookLeft", "LookRight", "Blink_L", "Blink_R",
+	}
+
+	buf := appendString(nil, "#bundle")
+	buf = appendUint32(buf, 0)
+	buf = appendUint32(buf, 1)
+
+	msg := appendString(nil, "/VMC/Ext/T")
+	msg = appendString(msg, ",f")
+	msg = appendFloats(msg, 1.5)
+	buf = appendElement(buf, msg)
+
+	msg = appendString(nil, "/VMC/Ext/Root/Pos")
+	msg = appendString(msg, ",sfffffffffffff")
+	msg = appendString(msg, "root")
+	msg = appendFloats(msg, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 0, 0, 0)
+	buf = appendElement(buf, msg)
+
+	for _, bone := range bones {
+		msg = appendString(nil, "/VMC/Ext/Bone/Pos")
+		msg = appendString(msg, ",sfffffff")
+		msg = appendString(msg, bone)
+		msg = appendFloats(msg, 0.1, 0.2, 0.3, 0, 0, 0, 1)
+		buf = appendElement(buf, msg)
+	}
+
+	for _, blendShape := range blendShapes {
+		msg = appendString(nil, "/VMC/Ext/Blend/Val")
+		msg = appendString(msg, ",sf")
+		msg = appendString(msg, blendShape)
+		msg = appendFloats(msg, 0.5)
+		buf = appendElement(buf, msg)
+	}
+
+	msg = appendString(nil, "/VMC/Ext/Blend/Apply")
+	msg = appendString(msg, ",")
+	buf = appendElement(buf, msg)
+
+	return buf
+}
+
+func benchmarkReadPacket(b *testing.B, input []byte) {
+	b.Helper()
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+
+	for i := 0; i < b.N; i++ {
+		if _, _, err := osc.ReadPacket(input); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadPacketMessage(b *testing.B) {
+	benchmarkReadPacket(b, []byte("/VMC/Ext/Bone/Pos\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a"))
+}
+
+func BenchmarkReadPacketBundle(b *testing.B) {
+	benchmarkReadPacket(b, fullBodyBundle())
+}
+
+func BenchmarkPacketIterate(b *testing.B) {
+	packet, _, err := osc.ReadPacket(fullBodyBundle())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		count := 0
+
+		_ = packet.Iterate(func(*osc.Message) error {
+			count++
+			return nil
+		})
+	}
+}
+
+func BenchmarkPacketToMessages(b *testing.B) {
+	packet, _, err := osc.ReadPacket(fullBodyBundle())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = packet.ToMessages()
+	}
+}
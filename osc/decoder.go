@@ -0,0 +1,114 @@
+package osc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrFrameRemainder occurs when a frame contains more data after the packet.
+var ErrFrameRemainder = errors.New("frame contains data after the packet")
+
+// Decoder reads a continuous stream of framed OSC packets, like a TCP connection or a serial link.
+// Partial reads of the underlying reader are handled transparently, and the frame buffer grows as
+// needed, up to the size limit.
+//
+// Packets are iterated in the style of bufio.Scanner:
+//
+//	decoder := osc.NewDecoder(conn, osc.FramingSLIP)
+//	for decoder.Next() {
+//		handle(decoder.Packet())
+//	}
+//
+//	if err := decoder.Err(); err != nil {
+//		return err
+//	}
+//
+// Frames that exceed the size limit are skipped, and decoding continues with the next frame, as
+// the stream is still intact. They're counted by Skipped. All other errors stop the decoder.
+//
+// A decoder must not be used concurrently.
+type Decoder struct {
+	// MaxSize is the size limit of a single frame. If zero, DefaultMaxFrameSize is used.
+	MaxSize int
+
+	frames  *FrameReader
+	packet  *Packet
+	skipped int
+	err     error
+}
+
+// NewDecoder creates a new decoder, that reads packets in frames of the given scheme from r.
+func NewDecoder(r io.Reader, framing Framing) *Decoder {
+	return &Decoder{
+		MaxSize: 0,
+		frames:  NewFrameReader(r, framing),
+		packet:  nil,
+		skipped: 0,
+		err:     nil,
+	}
+}
+
+// Next reads and parses the next packet, which is then available through Packet. It returns false
+// once the stream ended or an error occurred, after which Err tells the reason.
+func (d *Decoder) Next() bool {
+	d.packet = nil
+
+	if d.err != nil {
+		return false
+	}
+
+	d.frames.MaxSize = d.MaxSize
+
+	frame, err := d.frames.ReadFrame()
+
+	var tooLarge FrameTooLargeError
+	for errors.As(err, &tooLarge) {
+		d.skipped++
+		frame, err = d.frames.ReadFrame()
+	}
+
+	if err != nil {
+		d.err = err
+
+		return false
+	}
+
+	packet, remainder, err := ReadPacket(frame)
+	if err != nil {
+		d.err = fmt.Errorf("failed parsing packet: %w", err)
+
+		return false
+	}
+
+	if len(remainder) > 0 {
+		d.err = fmt.Errorf("%w: %d bytes", ErrFrameRemainder, len(remainder))
+
+		return false
+	}
+
+	d.packet = packet
+
+	return true
+}
+
+// Packet returns the packet, that was read by the last call to Next. It references the internal
+// buffer of the decoder, and is only valid until the next call to Next.
+func (d *Decoder) Packet() *Packet {
+	return d.packet
+}
+
+// Skipped returns the amount of frames, that were skipped as they exceeded the size limit.
+func (d *Decoder) Skipped() int {
+	return d.skipped
+}
+
+// Err returns the error, that stopped the decoder. It returns nil if the stream ended cleanly
+// between two frames. Streams that end inside a frame are reported as io.ErrUnexpectedEOF.
+func (d *Decoder) Err() error {
+	if errors.Is(d.err, io.EOF) {
+		return nil
+	}
+
+	return d.err
+}
@@ -0,0 +1,121 @@
+package osc_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder(t *testing.T) {
+	packets := [][]byte{
+		osc.AppendMessage(nil, "/a", []byte("i"), osc.AppendInt(nil, 1)),
+		osc.AppendMessage(nil, "/b", []byte("b"), osc.AppendBlob(nil, bytes.Repeat([]byte{0xc0}, 5000))),
+		osc.AppendElement(osc.AppendBundle(nil, osc.TimeTagImmediate), func(buf []byte) []byte {
+			return osc.AppendMessage(buf, "/c", nil, nil)
+		}),
+	}
+
+	for _, framing := range []osc.Framing{osc.FramingLengthPrefix, osc.FramingSLIP} {
+		framing := framing
+		t.Run(framing.String(), func(t *testing.T) {
+			var stream bytes.Buffer
+
+			writer := osc.NewFrameWriter(&stream, framing)
+			for _, packet := range packets {
+				require.NoError(t, writer.WriteFrame(packet))
+			}
+
+			// Every read returns a single byte, so all packets arrive in pieces.
+			decoder := osc.NewDecoder(iotest.OneByteReader(&stream), framing)
+
+			var addresses []string
+
+			for decoder.Next() {
+				require.NoError(t, decoder.Packet().Iterate(func(msg *osc.Message) error {
+					addresses = append(addresses, string(msg.Address))
+
+					return nil
+				}))
+			}
+
+			require.NoError(t, decoder.Err())
+			assert.Equal(t, []string{"/a", "/b", "/c"}, addresses)
+			assert.Nil(t, decoder.Packet())
+			assert.False(t, decoder.Next())
+		})
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	message := osc.AppendMessage(nil, "/a", nil, nil)
+
+	tests := []struct {
+		name   string
+		stream []byte
+		check  func(t *testing.T, err error)
+	}{
+		{
+			name:   "empty",
+			stream: nil,
+			check:  func(t *testing.T, err error) { assert.NoError(t, err) },
+		},
+		{
+			name:   "truncated",
+			stream: osc.AppendLengthPrefixFrame(nil, message)[:6],
+			check:  func(t *testing.T, err error) { assert.ErrorIs(t, err, io.ErrUnexpectedEOF) },
+		},
+		{
+			name:   "invalid",
+			stream: osc.AppendLengthPrefixFrame(nil, []byte("abcd")),
+			check:  func(t *testing.T, err error) { assert.ErrorIs(t, err, osc.ErrInvalidPacket) },
+		},
+		{
+			name:   "remainder",
+			stream: osc.AppendLengthPrefixFrame(nil, append(append([]byte(nil), message...), 0, 0, 0, 0)),
+			check:  func(t *testing.T, err error) { assert.ErrorIs(t, err, osc.ErrFrameRemainder) },
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := osc.NewDecoder(bytes.NewReader(tt.stream), osc.FramingLengthPrefix)
+			decoder.MaxSize = 32
+
+			assert.False(t, decoder.Next())
+			tt.check(t, decoder.Err())
+			assert.False(t, decoder.Next())
+		})
+	}
+}
+
+func TestDecoderSkipsLargeFrames(t *testing.T) {
+	message := osc.AppendMessage(nil, "/a", nil, nil)
+
+	for _, framing := range []osc.Framing{osc.FramingLengthPrefix, osc.FramingSLIP} {
+		framing := framing
+		t.Run(framing.String(), func(t *testing.T) {
+			var stream bytes.Buffer
+
+			writer := osc.NewFrameWriter(&stream, framing)
+			require.NoError(t, writer.WriteFrame(make([]byte, 64)))
+			require.NoError(t, writer.WriteFrame(make([]byte, 64)))
+			require.NoError(t, writer.WriteFrame(message))
+
+			decoder := osc.NewDecoder(&stream, framing)
+			decoder.MaxSize = 32
+
+			require.True(t, decoder.Next())
+			assert.Equal(t, "/a", string(decoder.Packet().Message.Address))
+			assert.Equal(t, 2, decoder.Skipped())
+
+			assert.False(t, decoder.Next())
+			assert.NoError(t, decoder.Err())
+		})
+	}
+}
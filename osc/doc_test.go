@@ -83,3 +83,44 @@ func ExampleMessage_useArguments() {
 	// arg 1: 5
 	// arg 2: true
 }
+
+func ExampleUnmarshal() {
+	// Arguments are bound to the struct fields in order. The optional field is left empty, as the
+	// message doesn't contain the argument.
+
+	type frequency struct {
+		Channel int32
+		Value   float32
+		Label   *string `osc:"s,optional"`
+	}
+
+	raw := []byte("/oscillator/4/frequency\x00,if\x00\x00\x00\x00\x04\x43\xdc\x00\x00")
+
+	packet, _, err := osc.ReadPacket(raw)
+	if err != nil {
+		panic(err)
+	}
+
+	var value frequency
+	if err := osc.Unmarshal(packet.Message, &value); err != nil {
+		panic(err)
+	}
+
+	fmt.Println(value.Channel, value.Value, value.Label)
+	// Output: 4 440 <nil>
+}
+
+func ExampleMarshal() {
+	type frequency struct {
+		Channel int32
+		Value   float32
+	}
+
+	raw, err := osc.Marshal("/oscillator/4/frequency", frequency{Channel: 4, Value: 440})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%q\n", raw)
+	// Output: "/oscillator/4/frequency\x00,if\x00\x00\x00\x00\x04C\xdc\x00\x00"
+}
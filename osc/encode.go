@@ -0,0 +1,127 @@
+package osc
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// AppendInt appends a 32-bit integer argument to the buffer.
+func AppendInt(buf []byte, value int32) []byte {
+	return appendUint32(buf, uint32(value))
+}
+
+// AppendFloat appends a 32-bit floating point argument to the buffer.
+func AppendFloat(buf []byte, value float32) []byte {
+	return appendUint32(buf, math.Float32bits(value))
+}
+
+// AppendString appends an OSC string to the buffer, including the terminator and padding.
+func AppendString(buf []byte, value string) []byte {
+	buf = append(buf, value...)
+
+	return appendPadding(buf, pad(len(value)))
+}
+
+// AppendStringBytes appends an OSC string, that is given as byte slice, to the buffer. It's the
+// counterpart to ReadString, and behaves the same as AppendString.
+func AppendStringBytes(buf, value []byte) []byte {
+	buf = append(buf, value...)
+
+	return appendPadding(buf, pad(len(value)))
+}
+
+// AppendBlob appends an OSC blob to the buffer, including the length prefix and padding.
+func AppendBlob(buf, value []byte) []byte {
+	buf = appendUint32(buf, uint32(len(value)))
+	buf = append(buf, value...)
+
+	return appendPadding(buf, blobPad(len(value)))
+}
+
+// AppendInt64 appends a 64-bit integer argument to the buffer.
+func AppendInt64(buf []byte, value int64) []byte {
+	return appendUint64(buf, uint64(value))
+}
+
+// AppendTimeTag appends an OSC time tag to the buffer.
+func AppendTimeTag(buf []byte, value int64) []byte {
+	return appendUint64(buf, uint64(value))
+}
+
+// AppendDouble appends a 64-bit floating point argument to the buffer.
+func AppendDouble(buf []byte, value float64) []byte {
+	return appendUint64(buf, math.Float64bits(value))
+}
+
+// AppendChar appends a 32-bit character argument to the buffer.
+func AppendChar(buf []byte, value rune) []byte {
+	return appendUint32(buf, uint32(value))
+}
+
+// AppendRgba appends a 32-bit RGBA color argument to the buffer.
+func AppendRgba(buf []byte, value [4]byte) []byte {
+	return append(buf, value[:]...)
+}
+
+// AppendMidi appends a 4 byte MIDI message argument to the buffer.
+func AppendMidi(buf []byte, value [4]byte) []byte {
+	return append(buf, value[:]...)
+}
+
+// AppendMessage appends a complete OSC message to the buffer. The type tags are given without the
+// leading comma, and the arguments must already be encoded, for example with the other Append
+// functions of this package.
+func AppendMessage(buf []byte, address string, typeTags, arguments []byte) []byte {
+	buf = AppendString(buf, address)
+	buf = append(buf, ',')
+	buf = append(buf, typeTags...)
+	buf = appendPadding(buf, pad(len(typeTags)+1))
+
+	return append(buf, arguments...)
+}
+
+// TimeTagImmediate is the time tag, that tells the receiver to process a bundle immediately.
+const TimeTagImmediate int64 = 1
+
+// AppendBundle appends the header of a bundle to the buffer. The contents are added afterwards,
+// with AppendElement.
+func AppendBundle(buf []byte, timeTag int64) []byte {
+	buf = AppendString(buf, "#bundle")
+
+	return AppendTimeTag(buf, timeTag)
+}
+
+// AppendElement appends a single element of a bundle to the buffer. The packet is appended by the
+// callback, and its length prefix filled in afterwards, which avoids encoding the packet twice.
+func AppendElement(buf []byte, appendPacket func(buf []byte) []byte) []byte {
+	start := len(buf)
+	buf = appendPacket(appendUint32(buf, 0))
+
+	binary.BigEndian.PutUint32(buf[start:], uint32(len(buf)-start-4))
+
+	return buf
+}
+
+func appendUint32(buf []byte, value uint32) []byte {
+	var raw [4]byte
+
+	binary.BigEndian.PutUint32(raw[:], value)
+
+	return append(buf, raw[:]...)
+}
+
+func appendUint64(buf []byte, value uint64) []byte {
+	var raw [8]byte
+
+	binary.BigEndian.PutUint64(raw[:], value)
+
+	return append(buf, raw[:]...)
+}
+
+func appendPadding(buf []byte, length int) []byte {
+	for i := 0; i < length; i++ {
+		buf = append(buf, 0)
+	}
+
+	return buf
+}
@@ -0,0 +1,91 @@
+package osc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendMessageSamples(t *testing.T) {
+	oscillator := osc.AppendMessage(nil, "/oscillator/4/frequency", []byte("f"), osc.AppendFloat(nil, 440))
+	assert.Equal(t, []byte("/oscillator/4/frequency\x00,f\x00\x00\x43\xdc\x00\x00"), oscillator)
+
+	var args []byte
+	args = osc.AppendInt(args, 1000)
+	args = osc.AppendInt(args, -1)
+	args = osc.AppendString(args, "hello")
+	args = osc.AppendFloat(args, 1.234)
+	args = osc.AppendFloat(args, 5.678)
+
+	foo := osc.AppendMessage(nil, "/foo", []byte("iisff"), args)
+	assert.Equal(t, []byte("/foo\x00\x00\x00\x00,iisff\x00\x00\x00\x00\x03\xe8\xff\xff\xff\xffhello\x00\x00\x00\x3f\x9d\xf3\xb6\x40\xb5\xb2\x2d"), foo)
+}
+
+func TestAppendRoundTrip(t *testing.T) {
+	var args []byte
+	args = osc.AppendInt(args, -5)
+	args = osc.AppendFloat(args, 0.5)
+	args = osc.AppendStringBytes(args, []byte("four"))
+	args = osc.AppendBlob(args, []byte{1, 2, 3, 4})
+	args = osc.AppendBlob(args, []byte{1, 2, 3})
+	args = osc.AppendInt64(args, -6)
+	args = osc.AppendTimeTag(args, 7)
+	args = osc.AppendDouble(args, 0.25)
+	args = osc.AppendString(args, "")
+	args = osc.AppendChar(args, 'x')
+	args = osc.AppendRgba(args, [4]byte{1, 2, 3, 4})
+	args = osc.AppendMidi(args, [4]byte{5, 6, 7, 8})
+
+	input := osc.AppendMessage(nil, "/all", []byte("ifsbbhtdScrmTFN"), args)
+
+	assertPacket(t, input, &osc.Packet{
+		Message: &osc.Message{
+			Address:  []byte("/all"),
+			TypeTags: []byte("ifsbbhtdScrmTFN"),
+			Arguments: []interface{}{
+				int32(-5),
+				float32(0.5),
+				[]byte("four"),
+				[]byte{1, 2, 3, 4},
+				[]byte{1, 2, 3},
+				int64(-6),
+				int64(7),
+				float64(0.25),
+				[]byte{},
+				'x',
+				[4]byte{1, 2, 3, 4},
+				[4]byte{5, 6, 7, 8},
+				true,
+				false,
+				nil,
+			},
+			Raw: input,
+		},
+	})
+}
+
+func TestAppendMessageNoArguments(t *testing.T) {
+	assert.Equal(t, []byte("/abc\x00\x00\x00\x00,\x00\x00\x00"), osc.AppendMessage(nil, "/abc", nil, nil))
+	assert.Equal(t, []byte("/ab\x00,ii\x00"), osc.AppendMessage(nil, "/ab", []byte("ii"), nil))
+}
+
+func TestAppendBundle(t *testing.T) {
+	buf := osc.AppendBundle(nil, osc.TimeTagImmediate)
+	buf = osc.AppendElement(buf, func(buf []byte) []byte {
+		return osc.AppendMessage(buf, "/a", []byte("i"), osc.AppendInt(nil, 1))
+	})
+	buf = osc.AppendElement(buf, func(buf []byte) []byte {
+		return osc.AppendMessage(buf, "/b", nil, nil)
+	})
+
+	assert.Equal(t, []byte("#bundle\x00\x00\x00\x00\x00\x00\x00\x00\x01"+
+		"\x00\x00\x00\x0c/a\x00\x00,i\x00\x00\x00\x00\x00\x01"+
+		"\x00\x00\x00\x08/b\x00\x00,\x00\x00\x00"), buf)
+
+	packet, rest, err := osc.ReadPacket(buf)
+	assert.NoError(t, err)
+	assert.Empty(t, rest)
+	assert.Equal(t, osc.TimeTagImmediate, packet.Bundle.TimeTag)
+	assert.Len(t, packet.Bundle.Contents, 2)
+}
@@ -0,0 +1,99 @@
+package osc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+)
+
+func addSeeds(f *testing.F) {
+	f.Helper()
+
+	seeds := []string{
+		"/oscillator/4/frequency\x00,f\x00\x00\x43\xdc\x00\x00",
+		"/foo\x00\x00\x00\x00,iisff\x00\x00\x00\x00\x03\xe8\xff\xff\xff\xffhello\x00\x00\x00\x3f\x9d\xf3\xb6\x40\xb5\xb2\x2d",
+		"#bundle\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0c/\x00\x00\x00,s\x00\x00hi\x00\x00",
+		"#bundle\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0c/a\x00\x00,i\x00\x00\x00\x00\x00\x01\x00\x00\x00\x0c/b\x00\x00,i\x00\x00\x00\x00\x00\x02",
+		"/\x00\x00\x00,b\x00\x00\x00\x00\x00\x03\x01\x02\x03\x00",
+		"/\x00\x00\x00,b\x00\x00\x00\x00\x00\x04\x01\x02\x03\x04",
+		"/\x00\x00\x00,hd\x00\x00\x00\x00\x00\x00\x00\x00\x05\x40\x14\x00\x00\x00\x00\x00\x00",
+		"/\x00\x00\x00,tcrm\x00\x00\x00\x00\x00\x00\x00\x00\x00\x05\x00\x00\x00a\x01\x02\x03\x04\x01\x02\x03\x04",
+		"/\x00\x00\x00,TFN|\x00\x00\x00",
+		"/\x00\x00\x00,S\x00\x00tst\x00",
+	}
+
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+}
+
+func FuzzReadPacket(f *testing.F) {
+	addSeeds(f)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		packet, rest, err := osc.ReadPacket(data)
+		if err != nil {
+			return
+		}
+
+		if (packet.Message == nil) == (packet.Bundle == nil) {
+			t.Fatalf("exactly one of message or bundle must be set: %v", packet)
+		}
+
+		if len(rest) > len(data) || !bytes.HasSuffix(data, rest) {
+			t.Fatalf("remaining data is not a suffix of the input")
+		}
+
+		count := 0
+		_ = packet.Iterate(func(*osc.Message) error {
+			count++
+			return nil
+		})
+
+		if messages := packet.ToMessages(); len(messages) != count {
+			t.Fatalf("iterated %d messages, but collected %d", count, len(messages))
+		}
+
+		_ = packet.String()
+	})
+}
+
+func FuzzReadString(f *testing.F) {
+	f.Add([]byte("tst\x00"))
+	f.Add([]byte("test\x00\x00\x00\x00"))
+	f.Add([]byte("\x00\x00\x00\x00"))
+	f.Add([]byte("ab\x00"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		value, rest, err := osc.ReadString(data)
+		if err != nil {
+			return
+		}
+
+		if bytes.IndexByte(value, 0) != -1 {
+			t.Fatalf("string value contains a 0 byte")
+		}
+
+		if consumed := len(data) - len(rest); consumed%4 != 0 || consumed <= len(value) {
+			t.Fatalf("invalid amount of consumed bytes %d for string of length %d", consumed, len(value))
+		}
+	})
+}
+
+func FuzzReadTypeTags(f *testing.F) {
+	f.Add([]byte(",\x00\x00\x00"))
+	f.Add([]byte(",iisff\x00\x00"))
+	f.Add([]byte(",sfffffff\x00\x00\x00"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		tags, rest, err := osc.ReadTypeTags(data)
+		if err != nil {
+			return
+		}
+
+		if consumed := len(data) - len(rest); consumed%4 != 0 || consumed <= len(tags)+1 {
+			t.Fatalf("invalid amount of consumed bytes %d for %d type tags", consumed, len(tags))
+		}
+	})
+}
@@ -0,0 +1,419 @@
+package osc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidTarget occurs when Marshal or Unmarshal are called with a value, that is not a struct
+// or a (non-nil) pointer to one.
+var ErrInvalidTarget = errors.New("target must be a struct or non-nil pointer to a struct")
+
+// TypeTagsMismatchError occurs when unmarshalling a message, which type tags don't match the fields
+// of the target struct.
+type TypeTagsMismatchError struct {
+	Found    []byte // Found are the type tags of the message.
+	Expected string // Expected are the type tags of all fields, including optional ones.
+	Required int    // Required is the amount of non-optional fields.
+}
+
+var _ error = (*TypeTagsMismatchError)(nil)
+
+func (e TypeTagsMismatchError) Error() string {
+	if e.Required < len(e.Expected) {
+		return fmt.Sprintf(
+			"type tags `%s` don't match `%s` (last %d optional)",
+			e.Found, e.Expected, len(e.Expected)-e.Required,
+		)
+	}
+
+	return fmt.Sprintf("type tags `%s` don't match `%s`", e.Found, e.Expected)
+}
+
+// FieldTagError occurs when the `osc` struct tag of a field is invalid, or the type tag doesn't fit
+// the Go type of the field.
+type FieldTagError struct {
+	Field  string // Field is the name of the struct field.
+	Tag    string // Tag is the content of the struct tag.
+	Reason string // Reason describes why the tag is invalid.
+}
+
+var _ error = (*FieldTagError)(nil)
+
+func (e FieldTagError) Error() string {
+	return fmt.Sprintf("invalid osc tag `%s` on field %s: %s", e.Tag, e.Field, e.Reason)
+}
+
+// Unmarshaler is implemented by types, that can bind the arguments of a message to themselves. It's
+// usually generated with the oscgen tool, to avoid reflection.
+type Unmarshaler interface {
+	UnmarshalOSC(msg *Message) error
+}
+
+// Marshaler is implemented by types, that can encode themselves as arguments of a message. It's
+// usually generated with the oscgen tool, to avoid reflection.
+type Marshaler interface {
+	// AppendOSC appends the type tags (without the leading comma) and encoded arguments to the
+	// given buffers.
+	AppendOSC(typeTags, arguments []byte) ([]byte, []byte, error)
+}
+
+// Unmarshal binds the arguments of a message positionally to the exported fields of the struct,
+// that v points to. If v implements Unmarshaler, its UnmarshalOSC method is used instead.
+//
+// The type tag of each field is given by the `osc` struct tag, or derived from the Go type of the
+// field. Fields tagged with `osc:"-"` are skipped. Trailing fields can be marked as optional with
+// `osc:",optional"` or `osc:"f,optional"`, in which case they're set to their zero value (or nil
+// for pointers), if the message is missing the argument. This covers messages with multiple
+// variants, that were extended over time. The supported types and tags are:
+//
+//	int32        i (default), c
+//	bool         T (default, true and false tags), i (zero and non-zero)
+//	float32      f (default)
+//	string       s (default), S, b
+//	[]byte       s (default), S, b
+//	int64        h (default), t
+//	float64      d (default)
+//	[4]byte      r, m
+//
+// Byte slices reference the message content, while strings are copied.
+func Unmarshal(msg *Message, v interface{}) error {
+	if u, ok := v.(Unmarshaler); ok {
+		return u.UnmarshalOSC(msg)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+
+	rv = rv.Elem()
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	expected, required := fieldTypeTags(fields)
+	if err := CheckTypeTags(msg.TypeTags, expected, required); err != nil {
+		return err
+	}
+
+	for i, field := range fields {
+		target := rv.Field(field.index)
+
+		if i >= len(msg.Arguments) {
+			target.Set(reflect.Zero(target.Type()))
+
+			continue
+		}
+
+		if field.pointer {
+			target.Set(reflect.New(target.Type().Elem()))
+			target = target.Elem()
+		}
+
+		setArgument(target, field.tag, msg.Arguments[i])
+	}
+
+	return nil
+}
+
+// Marshal encodes the struct v (or pointer to one) as a complete message with the given address.
+// If v implements Marshaler, its AppendOSC method is used instead.
+//
+// Fields are mapped the same way as for Unmarshal. Encoding stops at the first optional field with
+// a nil pointer, so later optional fields are omitted as well.
+func Marshal(address string, v interface{}) ([]byte, error) {
+	var (
+		typeTags, arguments []byte
+		err                 error
+	)
+
+	if m, ok := v.(Marshaler); ok {
+		typeTags, arguments, err = m.AppendOSC(nil, nil)
+	} else {
+		typeTags, arguments, err = marshalReflect(v)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return AppendMessage(nil, address, typeTags, arguments), nil
+}
+
+// CheckTypeTags validates the type tags of a message against the expected ones. The last fields
+// after the required amount are optional and may be missing. An expected `T` accepts both boolean
+// tags, `T` and `F`.
+//
+// It's used by Unmarshal, as well as the code generated by oscgen.
+func CheckTypeTags(found []byte, expected string, required int) error {
+	if len(found) < required || len(found) > len(expected) {
+		return TypeTagsMismatchError{Found: found, Expected: expected, Required: required}
+	}
+
+	for i, tag := range found {
+		if tag == expected[i] || (expected[i] == TypeTagTrue && tag == TypeTagFalse) {
+			continue
+		}
+
+		return TypeTagsMismatchError{Found: found, Expected: expected, Required: required}
+	}
+
+	return nil
+}
+
+func marshalReflect(v interface{}) ([]byte, []byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, nil, ErrInvalidTarget
+	}
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	typeTags := make([]byte, 0, len(fields))
+	arguments := make([]byte, 0, 4*len(fields))
+
+	for _, field := range fields {
+		value := rv.Field(field.index)
+
+		if field.pointer {
+			if value.IsNil() {
+				break
+			}
+
+			value = value.Elem()
+		}
+
+		typeTags, arguments = appendArgument(typeTags, arguments, field.tag, value)
+	}
+
+	return typeTags, arguments, nil
+}
+
+type structField struct {
+	index    int
+	tag      byte
+	optional bool
+	pointer  bool
+}
+
+func structFields(typ reflect.Type) ([]structField, error) {
+	fields := make([]structField, 0, typ.NumField())
+	optional := false
+
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		raw := sf.Tag.Get("osc")
+		if raw == "-" {
+			continue
+		}
+
+		field, err := parseField(sf, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		field.index = i
+
+		if optional && !field.optional {
+			return nil, FieldTagError{Field: sf.Name, Tag: raw, Reason: "required field after optional one"}
+		}
+
+		optional = field.optional
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func parseField(sf reflect.StructField, raw string) (structField, error) {
+	field := structField{index: 0, tag: 0, optional: false, pointer: false}
+	name, options, _ := strings.Cut(raw, ",")
+
+	switch options {
+	case "":
+	case "optional":
+		field.optional = true
+	default:
+		return field, FieldTagError{Field: sf.Name, Tag: raw, Reason: "unknown option"}
+	}
+
+	typ := sf.Type
+	if typ.Kind() == reflect.Pointer {
+		if !field.optional {
+			return field, FieldTagError{Field: sf.Name, Tag: raw, Reason: "pointers must be optional"}
+		}
+
+		field.pointer = true
+		typ = typ.Elem()
+	}
+
+	switch len(name) {
+	case 0:
+		tag, ok := defaultTypeTag(typ)
+		if !ok {
+			return field, FieldTagError{Field: sf.Name, Tag: raw, Reason: "unsupported type " + typ.String()}
+		}
+
+		field.tag = tag
+	case 1:
+		if !typeTagFits(name[0], typ) {
+			return field, FieldTagError{
+				Field:  sf.Name,
+				Tag:    raw,
+				Reason: "type tag doesn't fit " + typ.String(),
+			}
+		}
+
+		field.tag = name[0]
+	default:
+		return field, FieldTagError{Field: sf.Name, Tag: raw, Reason: "expected a single type tag"}
+	}
+
+	return field, nil
+}
+
+func fieldTypeTags(fields []structField) (string, int) {
+	var sb strings.Builder
+
+	required := 0
+
+	for _, field := range fields {
+		sb.WriteByte(field.tag)
+
+		if !field.optional {
+			required++
+		}
+	}
+
+	return sb.String(), required
+}
+
+func defaultTypeTag(typ reflect.Type) (byte, bool) {
+	switch {
+	case typ.Kind() == reflect.Int32:
+		return TypeTagInt, true
+	case typ.Kind() == reflect.Bool:
+		return TypeTagTrue, true
+	case typ.Kind() == reflect.Float32:
+		return TypeTagFloat, true
+	case typ.Kind() == reflect.String, isBytes(typ):
+		return TypeTagString, true
+	case typ.Kind() == reflect.Int64:
+		return TypeTagInt64, true
+	case typ.Kind() == reflect.Float64:
+		return TypeTagDouble, true
+	default:
+		return 0, false
+	}
+}
+
+func typeTagFits(tag byte, typ reflect.Type) bool {
+	switch tag {
+	case TypeTagInt:
+		return typ.Kind() == reflect.Int32 || typ.Kind() == reflect.Bool
+	case TypeTagChar:
+		return typ.Kind() == reflect.Int32
+	case TypeTagFloat:
+		return typ.Kind() == reflect.Float32
+	case TypeTagString, TypeTagSymbol, TypeTagBlob:
+		return typ.Kind() == reflect.String || isBytes(typ)
+	case TypeTagInt64, TypeTagTimeTag:
+		return typ.Kind() == reflect.Int64
+	case TypeTagDouble:
+		return typ.Kind() == reflect.Float64
+	case TypeTagRgba, TypeTagMidi:
+		return typ.Kind() == reflect.Array && typ.Len() == 4 && typ.Elem().Kind() == reflect.Uint8
+	case TypeTagTrue:
+		return typ.Kind() == reflect.Bool
+	default:
+		return false
+	}
+}
+
+func isBytes(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8
+}
+
+// setArgument stores the argument in the target field. The type tags were validated before, so the
+// argument is guaranteed to match the field.
+func setArgument(target reflect.Value, tag byte, argument interface{}) {
+	switch {
+	case tag == TypeTagInt && target.Kind() == reflect.Bool:
+		target.SetBool(argument.(int32) != 0)
+	case tag == TypeTagTrue:
+		target.SetBool(argument.(bool))
+	default:
+		target.Set(reflect.ValueOf(argument).Convert(target.Type()))
+	}
+}
+
+func appendArgument(typeTags, arguments []byte, tag byte, value reflect.Value) ([]byte, []byte) {
+	switch tag {
+	case TypeTagInt:
+		if value.Kind() == reflect.Bool {
+			return append(typeTags, tag), AppendInt(arguments, boolInt(value.Bool()))
+		}
+
+		return append(typeTags, tag), AppendInt(arguments, int32(value.Int()))
+	case TypeTagChar:
+		return append(typeTags, tag), AppendChar(arguments, rune(value.Int()))
+	case TypeTagFloat:
+		return append(typeTags, tag), AppendFloat(arguments, float32(value.Float()))
+	case TypeTagString, TypeTagSymbol:
+		return append(typeTags, tag), AppendStringBytes(arguments, reflectBytes(value))
+	case TypeTagBlob:
+		return append(typeTags, tag), AppendBlob(arguments, reflectBytes(value))
+	case TypeTagInt64:
+		return append(typeTags, tag), AppendInt64(arguments, value.Int())
+	case TypeTagTimeTag:
+		return append(typeTags, tag), AppendTimeTag(arguments, value.Int())
+	case TypeTagDouble:
+		return append(typeTags, tag), AppendDouble(arguments, value.Float())
+	case TypeTagRgba, TypeTagMidi:
+		var raw [4]byte
+
+		reflect.Copy(reflect.ValueOf(raw[:]), value)
+
+		return append(typeTags, tag), append(arguments, raw[:]...)
+	case TypeTagTrue:
+		if value.Bool() {
+			return append(typeTags, TypeTagTrue), arguments
+		}
+
+		return append(typeTags, TypeTagFalse), arguments
+	default:
+		return typeTags, arguments
+	}
+}
+
+func reflectBytes(value reflect.Value) []byte {
+	if value.Kind() == reflect.String {
+		return []byte(value.String())
+	}
+
+	return value.Bytes()
+}
+
+func boolInt(value bool) int32 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
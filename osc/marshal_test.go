@@ -0,0 +1,218 @@
+package osc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindTransform struct {
+	Name     string
+	Position [3]float32 `osc:"-"`
+	X, Y, Z  float32
+	Active   bool    `osc:"i"`
+	Local    *bool   `osc:"i,optional"`
+	Scale    float32 `osc:",optional"`
+}
+
+type bindEverything struct {
+	Int     int32
+	Char    rune `osc:"c"`
+	Bool    bool
+	Float   float32
+	String  []byte
+	Symbol  string `osc:"S"`
+	Blob    []byte `osc:"b"`
+	Int64   int64
+	TimeTag int64 `osc:"t"`
+	Double  float64
+	Rgba    [4]byte `osc:"r"`
+	Midi    [4]byte `osc:"m"`
+}
+
+func unmarshalPacket(t *testing.T, data []byte, v interface{}) error {
+	t.Helper()
+
+	packet, _, err := osc.ReadPacket(data)
+	require.NoError(t, err)
+	require.NotNil(t, packet.Message)
+
+	return osc.Unmarshal(packet.Message, v)
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	input := bindEverything{
+		Int:     -1,
+		Char:    'a',
+		Bool:    true,
+		Float:   1.5,
+		String:  []byte("hello"),
+		Symbol:  "sym",
+		Blob:    []byte{1, 2, 3},
+		Int64:   -2,
+		TimeTag: 3,
+		Double:  4.5,
+		Rgba:    [4]byte{1, 2, 3, 4},
+		Midi:    [4]byte{5, 6, 7, 8},
+	}
+
+	data, err := osc.Marshal("/everything", &input)
+	require.NoError(t, err)
+
+	packet, _, err := osc.ReadPacket(data)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("icTfsSbhtdrm"), packet.Message.TypeTags)
+
+	var output bindEverything
+	require.NoError(t, osc.Unmarshal(packet.Message, &output))
+	assert.Equal(t, input, output)
+}
+
+func TestMarshalOptional(t *testing.T) {
+	local := true
+
+	withLocal, err := osc.Marshal("/t", bindTransform{Name: "a", X: 1, Y: 2, Z: 3, Active: true, Local: &local, Scale: 4})
+	require.NoError(t, err)
+	assert.Equal(t, osc.AppendMessage(nil, "/t", []byte("sfffiif"), appendFields("a", 1.0, 2.0, 3.0, 1, 1, 4.0)), withLocal)
+
+	// A nil pointer stops the encoding, so the following optional fields are omitted as well.
+	withoutLocal, err := osc.Marshal("/t", bindTransform{Name: "a", X: 1, Y: 2, Z: 3, Scale: 4})
+	require.NoError(t, err)
+	assert.Equal(t, osc.AppendMessage(nil, "/t", []byte("sfffi"), appendFields("a", 1.0, 2.0, 3.0, 0)), withoutLocal)
+}
+
+func TestUnmarshalOptional(t *testing.T) {
+	local := false
+
+	var full bindTransform
+	require.NoError(t, unmarshalPacket(t,
+		osc.AppendMessage(nil, "/t", []byte("sfffiif"), appendFields("a", 1.0, 2.0, 3.0, 1, 0, 4.0)), &full))
+	assert.Equal(t, bindTransform{Name: "a", X: 1, Y: 2, Z: 3, Active: true, Local: &local, Scale: 4}, full)
+
+	// Missing optional arguments reset previous values.
+	partial := full
+	require.NoError(t, unmarshalPacket(t,
+		osc.AppendMessage(nil, "/t", []byte("sfffi"), appendFields("b", 4.0, 5.0, 6.0, 0)), &partial))
+	assert.Equal(t, bindTransform{Name: "b", X: 4, Y: 5, Z: 6}, partial)
+}
+
+func TestUnmarshalTypeTagsMismatch(t *testing.T) {
+	tests := []struct {
+		name string
+		tags string
+		args []byte
+	}{
+		{"too short", "sfff", appendFields("a", 1.0, 2.0, 3.0)},
+		{"too long", "sfffiifi", appendFields("a", 1.0, 2.0, 3.0, 1, 1, 4.0, 0)},
+		{"wrong type", "sffff", appendFields("a", 1.0, 2.0, 3.0, 1.0)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var output bindTransform
+
+			err := unmarshalPacket(t, osc.AppendMessage(nil, "/t", []byte(tt.tags), tt.args), &output)
+
+			var mismatch osc.TypeTagsMismatchError
+			require.ErrorAs(t, err, &mismatch)
+			assert.Equal(t, osc.TypeTagsMismatchError{Found: []byte(tt.tags), Expected: "sfffiif", Required: 5}, mismatch)
+		})
+	}
+}
+
+func TestUnmarshalBoolTags(t *testing.T) {
+	var output struct{ A, B bool }
+
+	require.NoError(t, unmarshalPacket(t, osc.AppendMessage(nil, "/b", []byte("TF"), nil), &output))
+	assert.True(t, output.A)
+	assert.False(t, output.B)
+
+	require.NoError(t, unmarshalPacket(t, osc.AppendMessage(nil, "/b", []byte("FT"), nil), &output))
+	assert.False(t, output.A)
+	assert.True(t, output.B)
+}
+
+func TestBindInvalidTarget(t *testing.T) {
+	var msg osc.Message
+
+	var value bindTransform
+	assert.ErrorIs(t, osc.Unmarshal(&msg, value), osc.ErrInvalidTarget)
+	assert.ErrorIs(t, osc.Unmarshal(&msg, (*bindTransform)(nil)), osc.ErrInvalidTarget)
+	assert.ErrorIs(t, osc.Unmarshal(&msg, new(int32)), osc.ErrInvalidTarget)
+
+	_, err := osc.Marshal("/x", 5)
+	assert.ErrorIs(t, err, osc.ErrInvalidTarget)
+}
+
+func TestBindFieldTagErrors(t *testing.T) {
+	var msg osc.Message
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		reason string
+	}{
+		{"unknown tag", &struct {
+			A int32 `osc:"x"`
+		}{}, "type tag doesn't fit int32"},
+		{"mismatching tag", &struct {
+			A float32 `osc:"i"`
+		}{}, "type tag doesn't fit float32"},
+		{"unknown option", &struct {
+			A int32 `osc:"i,required"`
+		}{}, "unknown option"},
+		{"multiple tags", &struct {
+			A int32 `osc:"ii"`
+		}{}, "expected a single type tag"},
+		{"unsupported type", &struct{ A int }{}, "unsupported type int"},
+		{"required pointer", &struct{ A *int32 }{}, "pointers must be optional"},
+		{"required after optional", &struct {
+			A int32 `osc:",optional"`
+			B int32
+		}{}, "required field after optional one"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var fieldErr osc.FieldTagError
+
+			require.ErrorAs(t, osc.Unmarshal(&msg, tt.value), &fieldErr)
+			assert.Equal(t, tt.reason, fieldErr.Reason)
+
+			_, err := osc.Marshal("/x", tt.value)
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.reason, fieldErr.Reason)
+		})
+	}
+}
+
+func TestCheckTypeTags(t *testing.T) {
+	assert.NoError(t, osc.CheckTypeTags([]byte("if"), "if", 2))
+	assert.NoError(t, osc.CheckTypeTags([]byte("iF"), "iT", 2))
+	assert.NoError(t, osc.CheckTypeTags([]byte("i"), "if", 1))
+	assert.Error(t, osc.CheckTypeTags([]byte("iT"), "iF", 2))
+	assert.Error(t, osc.CheckTypeTags([]byte("i"), "if", 2))
+	assert.Error(t, osc.CheckTypeTags([]byte("ifi"), "if", 1))
+}
+
+// appendFields encodes strings, integers as int32 and floating point numbers as float32.
+func appendFields(values ...interface{}) []byte {
+	var buf []byte
+
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			buf = osc.AppendString(buf, v)
+		case int:
+			buf = osc.AppendInt(buf, int32(v))
+		case float64:
+			buf = osc.AppendFloat(buf, float32(v))
+		}
+	}
+
+	return buf
+}
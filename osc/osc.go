@@ -1,12 +1,20 @@
 // Package osc implements parsing of "Open Sound Control" packets, in a read-only fashion.
 //
-// Only reading/parsing is implemented and allocations are avoided wherever possible. The use case
-// is to only inspect OSC packets and then pass them on to some other application for handling.
+// Reading/parsing avoids allocations wherever possible. The use case is to only inspect OSC
+// packets and then pass them on to some other application for handling.
+//
+// For creating packets, the Append functions encode single arguments and whole messages. The
+// Marshal and Unmarshal functions bind message arguments to struct fields, either by reflection or
+// through methods generated by the oscgen tool.
+//
+// Stream transports like TCP or serial links need the packets to be delimited. FrameReader and
+// FrameWriter handle this with either of the framing schemes of OSC 1.0 and 1.1.
 package osc
 
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // Possible errors while reading OSC packets.
@@ -30,6 +38,67 @@ func (e UnknownTypeTagError) Error() string {
 	return fmt.Sprintf("unknown type tag `%c`", e.Tag)
 }
 
+// ParseError describes the location inside a packet, where parsing failed. The underlying error is
+// wrapped, so it can still be checked against the other errors of this package with errors.Is or
+// errors.As.
+type ParseError struct {
+	Offset   int    // Offset is the byte position, relative to the start of the packet.
+	Argument int    // Argument is the index of the failed argument, or -1 if not inside arguments.
+	Path     []int  // Path contains the element indices of (nested) bundles, that lead to the message.
+	Address  string // Address of the message, if it was already parsed.
+	Err      error  // Err is the underlying error.
+}
+
+var _ error = (*ParseError)(nil)
+
+func (e ParseError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString("failed parsing")
+
+	if e.Address != "" {
+		fmt.Fprintf(&sb, " message `%s`", e.Address)
+	}
+
+	if len(e.Path) > 0 {
+		sb.WriteString(" in bundle element ")
+
+		for i, element := range e.Path {
+			if i > 0 {
+				sb.WriteByte('/')
+			}
+
+			fmt.Fprintf(&sb, "%d", element)
+		}
+	}
+
+	if e.Argument >= 0 {
+		fmt.Fprintf(&sb, " at argument %d", e.Argument)
+	}
+
+	fmt.Fprintf(&sb, " (byte %d): %v", e.Offset, e.Err)
+
+	return sb.String()
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+// nestError moves a parse error, that happened inside a bundle element, into the context of the
+// bundle.
+func nestError(err error, offset, element int) error {
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) {
+		parseErr = ParseError{Offset: 0, Argument: -1, Path: nil, Address: "", Err: err}
+	}
+
+	parseErr.Offset += offset
+	parseErr.Path = append([]int{element}, parseErr.Path...)
+
+	return parseErr
+}
+
 // Standard OSC type tags.
 const (
 	TypeTagInt    = 'i' // 32-bit integer.
@@ -121,6 +190,9 @@ func (p Packet) ToMessages() []*Message {
 
 // ReadPacket reads and parses a raw byte slice into a OSC packet. The remaining bytes (if any) are
 // returned for further processing by the user, as well.
+//
+// Errors that happen while reading the content of a message or bundle are returned as ParseError,
+// describing the location of the failure.
 func ReadPacket(buf []byte) (*Packet, []byte, error) {
 	if len(buf) == 0 {
 		return nil, nil, ErrInputEmpty
@@ -211,102 +283,41 @@ func readMessage(buf []byte) (*Message, []byte, error) {
 
 	address, newBuf, err := ReadString(buf)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed reading address: %w", err)
+		return nil, nil, ParseError{
+			Offset:   0,
+			Argument: -1,
+			Path:     nil,
+			Address:  "",
+			Err:      fmt.Errorf("failed reading address: %w", err),
+		}
 	}
 	buf = newBuf
 
 	typeTags, newBuf, err := ReadTypeTags(buf)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed reading type tags: %w", err)
+		return nil, nil, ParseError{
+			Offset:   len(raw) - len(buf),
+			Argument: -1,
+			Path:     nil,
+			Address:  string(address),
+			Err:      fmt.Errorf("failed reading type tags: %w", err),
+		}
 	}
 	buf = newBuf
 
-	arguments := make([]interface{}, len(typeTags))
-
-	for idx, tag := range typeTags {
-		switch tag {
-		case TypeTagInt:
-			v, b, err := readInt(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagFloat:
-			v, b, err := readFloat(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagString, TypeTagSymbol:
-			v, b, err := ReadString(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagBlob:
-			v, b, err := readBlob(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagInt64:
-			v, b, err := readInt64(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagTimeTag:
-			v, b, err := readTimeTag(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagDouble:
-			v, b, err := readDouble(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagChar:
-			v, b, err := readChar(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagRgba:
-			v, b, err := readRgba(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagMidi:
-			v, b, err := readMidi(buf)
-			if err != nil {
-				return nil, nil, err
-			}
-			buf = b
-			arguments[idx] = v
-		case TypeTagTrue:
-			arguments[idx] = true
-		case TypeTagFalse:
-			arguments[idx] = false
-		case TypeTagNil, TypeTagInfinitum:
-			arguments[idx] = nil
-		case TypeTagArrayStart, TypeTagArrayEnd:
-			return nil, nil, ErrArraysNotSupported
-		default:
-			return nil, nil, UnknownTypeTagError{Tag: tag}
+	arguments, newBuf, err := ReadArguments(make([]interface{}, 0, len(typeTags)), typeTags, buf)
+	if err != nil {
+		var parseErr ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, nil, err
 		}
+
+		parseErr.Offset += len(raw) - len(buf)
+		parseErr.Address = string(address)
+
+		return nil, nil, parseErr
 	}
+	buf = newBuf
 
 	return &Message{
 		Address:   address,
@@ -316,6 +327,77 @@ func readMessage(buf []byte) (*Message, []byte, error) {
 	}, buf, nil
 }
 
+// ReadArguments reads the arguments, as described by the type tags, from the start of the given
+// buffer and appends them to dst, which may be nil. The mapping from type tags to Go types is the
+// same as for the arguments of a Message.
+//
+// It returns the arguments with the advanced buffer, or a ParseError with the offset relative to
+// the start of the buffer, if decoding failed.
+func ReadArguments(dst []interface{}, typeTags, buf []byte) ([]interface{}, []byte, error) {
+	raw := buf
+
+	for idx, tag := range typeTags {
+		v, b, err := readArgument(tag, buf)
+		if err != nil {
+			return nil, nil, ParseError{
+				Offset:   len(raw) - len(buf),
+				Argument: idx,
+				Path:     nil,
+				Address:  "",
+				Err:      err,
+			}
+		}
+		buf = b
+		dst = append(dst, v)
+	}
+
+	return dst, buf, nil
+}
+
+func readArgument(tag byte, buf []byte) (interface{}, []byte, error) {
+	switch tag {
+	case TypeTagInt:
+		return wrapArgument(readInt(buf))
+	case TypeTagFloat:
+		return wrapArgument(readFloat(buf))
+	case TypeTagString, TypeTagSymbol:
+		return wrapArgument(ReadString(buf))
+	case TypeTagBlob:
+		return wrapArgument(readBlob(buf))
+	case TypeTagInt64:
+		return wrapArgument(readInt64(buf))
+	case TypeTagTimeTag:
+		return wrapArgument(readTimeTag(buf))
+	case TypeTagDouble:
+		return wrapArgument(readDouble(buf))
+	case TypeTagChar:
+		return wrapArgument(readChar(buf))
+	case TypeTagRgba:
+		return wrapArgument(readRgba(buf))
+	case TypeTagMidi:
+		return wrapArgument(readMidi(buf))
+	case TypeTagTrue:
+		return true, buf, nil
+	case TypeTagFalse:
+		return false, buf, nil
+	case TypeTagNil, TypeTagInfinitum:
+		return nil, buf, nil
+	case TypeTagArrayStart, TypeTagArrayEnd:
+		return nil, nil, ErrArraysNotSupported
+	default:
+		return nil, nil, UnknownTypeTagError{Tag: tag}
+	}
+}
+
+// wrapArgument turns the typed result of any of the read functions into a generic argument.
+func wrapArgument[T any](value T, buf []byte, err error) (interface{}, []byte, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return value, buf, nil
+}
+
 // ReadTypeTags reads the OSC type tags from the start of the given buffer, and returns it with the
 // advanced buffer, or an error if decoding failed.
 func ReadTypeTags(buf []byte) ([]byte, []byte, error) {
@@ -354,19 +436,21 @@ func IsBundle(buf []byte) bool {
 }
 
 func readBundle(buf []byte) (*Bundle, []byte, error) {
+	raw := buf
+
 	ident, newBuf, err := ReadString(buf)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, bundleError(0, err)
 	}
 	buf = newBuf
 
 	if string(ident) != "#bundle" {
-		return nil, nil, ErrInvalidBundleIdentifier
+		return nil, nil, bundleError(0, ErrInvalidBundleIdentifier)
 	}
 
 	timeTag, newBuf, err := readTimeTag(buf)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, bundleError(len(raw)-len(buf), err)
 	}
 	buf = newBuf
 
@@ -375,19 +459,19 @@ func readBundle(buf []byte) (*Bundle, []byte, error) {
 	for len(buf) > 4 {
 		length, newBuf, err := readLength(buf)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, bundleError(len(raw)-len(buf), err)
 		}
 		buf = newBuf
 
 		if len(buf) < length {
-			return nil, nil, ErrElementTooShort
+			return nil, nil, bundleError(len(raw)-len(buf), ErrElementTooShort)
 		}
 
-		packet, newBuf, err := ReadPacket(buf)
+		packet, _, err := ReadPacket(buf[:length])
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nestError(err, len(raw)-len(buf), len(contents))
 		}
-		buf = newBuf
+		buf = buf[length:]
 
 		contents = append(contents, *packet)
 	}
@@ -397,3 +481,7 @@ func readBundle(buf []byte) (*Bundle, []byte, error) {
 		Contents: contents,
 	}, buf, nil
 }
+
+func bundleError(offset int, err error) error {
+	return ParseError{Offset: offset, Argument: -1, Path: nil, Address: "", Err: err}
+}
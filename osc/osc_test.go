@@ -108,3 +108,67 @@ func TestPacketToMessages(t *testing.T) {
 	got := packet.ToMessages()
 	assert.Equal(t, want, got)
 }
+
+func TestParseErrorLocation(t *testing.T) {
+	input := []byte("#bundle\x00\x00\x00\x00\x00\x00\x00\x00\x01" +
+		"\x00\x00\x00\x0c/a\x00\x00,i\x00\x00\x00\x00\x00\x01" +
+		"\x00\x00\x00\x20#bundle\x00\x00\x00\x00\x00\x00\x00\x00\x01" +
+		"\x00\x00\x00\x0c/b\x00\x00,ii\x00\x00\x00\x00\x01")
+
+	_, _, err := osc.ReadPacket(input)
+	assert.ErrorIs(t, err, osc.ErrIntTooShort)
+
+	var parseErr osc.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 68, parseErr.Offset)
+	assert.Equal(t, 1, parseErr.Argument)
+	assert.Equal(t, []int{1, 0}, parseErr.Path)
+	assert.Equal(t, "/b", parseErr.Address)
+	assert.EqualError(t, err, "failed parsing message `/b` in bundle element 1/0 at argument 1 (byte 68): "+
+		"content is too short for an int")
+}
+
+func TestParseErrorTypeTags(t *testing.T) {
+	_, _, err := osc.ReadPacket([]byte("/a\x00\x00i\x00\x00\x00"))
+	assert.ErrorIs(t, err, osc.ErrTypeTagsStartMissing)
+
+	var parseErr osc.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, osc.ParseError{
+		Offset:   4,
+		Argument: -1,
+		Address:  "/a",
+		Err:      parseErr.Err,
+	}, parseErr)
+}
+
+func TestBundleElementBounds(t *testing.T) {
+	// The element length only covers the address, so the type tags must not be read from beyond
+	// the element.
+	input := []byte("#bundle\x00\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x04/\x00\x00\x00,i\x00\x00\x00\x00\x00\x01")
+
+	_, _, err := osc.ReadPacket(input)
+	assert.ErrorIs(t, err, osc.ErrTypeTagsStartMissing)
+
+	var parseErr osc.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, []int{0}, parseErr.Path)
+	assert.Equal(t, 24, parseErr.Offset)
+}
+
+func TestReadArguments(t *testing.T) {
+	dst := make([]interface{}, 0, 2)
+
+	args, buf, err := osc.ReadArguments(dst, []byte("iT"), []byte("\x00\x00\x00\x05rest"))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int32(5), true}, args)
+	assert.Equal(t, []byte("rest"), buf)
+
+	_, _, err = osc.ReadArguments(nil, []byte("fi"), []byte("\x3f\x80\x00\x00\x00"))
+	assert.ErrorIs(t, err, osc.ErrIntTooShort)
+
+	var parseErr osc.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 4, parseErr.Offset)
+	assert.Equal(t, 1, parseErr.Argument)
+}
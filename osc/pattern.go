@@ -0,0 +1,113 @@
+package osc
+
+import (
+	"bytes"
+	"strings"
+)
+
+// MatchAddress tells whether the address matches the OSC address pattern. Patterns support the
+// wildcards of OSC 1.0, which never match across a '/':
+//
+//	?          Any single character.
+//	*          Any sequence of characters, including an empty one.
+//	[abc]      Any of the listed characters. Ranges like a-z are allowed, and a leading '!'
+//	           negates the list.
+//	{foo,bar}  Any of the comma separated strings.
+//
+// Malformed patterns, like an unclosed '[', don't match any address.
+func MatchAddress(pattern string, address []byte) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '?':
+			if len(address) == 0 || address[0] == '/' {
+				return false
+			}
+
+			pattern, address = pattern[1:], address[1:]
+		case '*':
+			pattern = strings.TrimLeft(pattern, "*")
+
+			for i := 0; i <= len(address); i++ {
+				if MatchAddress(pattern, address[i:]) {
+					return true
+				}
+
+				if i < len(address) && address[i] == '/' {
+					break
+				}
+			}
+
+			return false
+		case '[':
+			end := strings.IndexByte(pattern, ']')
+			if end < 0 || len(address) == 0 || address[0] == '/' || !matchSet(pattern[1:end], address[0]) {
+				return false
+			}
+
+			pattern, address = pattern[end+1:], address[1:]
+		case '{':
+			end := strings.IndexByte(pattern, '}')
+			if end < 0 {
+				return false
+			}
+
+			return matchAlternatives(pattern[1:end], pattern[end+1:], address)
+		default:
+			if len(address) == 0 || address[0] != pattern[0] {
+				return false
+			}
+
+			pattern, address = pattern[1:], address[1:]
+		}
+	}
+
+	return len(address) == 0
+}
+
+// matchSet tells whether the character is part of a character list, like "a-z_".
+func matchSet(set string, c byte) bool {
+	negate := strings.HasPrefix(set, "!")
+	if negate {
+		set = set[1:]
+	}
+
+	for i := 0; i < len(set); i++ {
+		if i+2 < len(set) && set[i+1] == '-' {
+			if c >= set[i] && c <= set[i+2] {
+				return !negate
+			}
+
+			i += 2
+
+			continue
+		}
+
+		if c == set[i] {
+			return !negate
+		}
+	}
+
+	return negate
+}
+
+// matchAlternatives tells whether the address starts with any of the comma separated alternatives,
+// followed by the rest of the pattern.
+func matchAlternatives(alternatives, rest string, address []byte) bool {
+	for {
+		alternative := alternatives
+
+		comma := strings.IndexByte(alternatives, ',')
+		if comma >= 0 {
+			alternative, alternatives = alternatives[:comma], alternatives[comma+1:]
+		}
+
+		if bytes.HasPrefix(address, []byte(alternative)) &&
+			MatchAddress(rest, address[len(alternative):]) {
+			return true
+		}
+
+		if comma < 0 {
+			return false
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package osc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchAddress(t *testing.T) {
+	tests := []struct {
+		pattern string
+		address string
+		want    bool
+	}{
+		{"/VMC/Ext/Bone/Pos", "/VMC/Ext/Bone/Pos", true},
+		{"/VMC/Ext/Bone/Pos", "/VMC/Ext/Bone/Po", false},
+		{"/VMC/Ext/Bone/*", "/VMC/Ext/Bone/Pos", true},
+		{"/VMC/Ext/*", "/VMC/Ext/Bone/Pos", false},
+		{"/VMC/Ext/*/Pos", "/VMC/Ext/Hmd/Pos", true},
+		{"/VMC/Ext/*/Pos/*", "/VMC/Ext/Hmd/Pos/Local", true},
+		{"/VMC/Ext/*", "/VMC/Ext/", true},
+		{"/VMC/Ext/**T", "/VMC/Ext/T", true},
+		{"/VMC/Ext/?", "/VMC/Ext/T", true},
+		{"/VMC/Ext/?", "/VMC/Ext/OK", false},
+		{"/VMC/Ext/Blend/{Val,Apply}", "/VMC/Ext/Blend/Apply", true},
+		{"/VMC/Ext/Blend/{Val,Apply}", "/VMC/Ext/Blend/Other", false},
+		{"/VMC/Ext/{Hmd,Con,Tra}/Pos", "/VMC/Ext/Tra/Pos", true},
+		{"/VMC/Ext/{Con,Con/Pos}", "/VMC/Ext/Con/Pos", true},
+		{"/VMC/Ext/[A-Z]", "/VMC/Ext/T", true},
+		{"/VMC/Ext/[!A-Z]", "/VMC/Ext/T", false},
+		{"/VMC/Ext/[!a-z]", "/VMC/Ext/T", true},
+		{"/VMC/Ext/[KT]", "/VMC/Ext/K", true},
+		{"/VMC/Ext/[KT", "/VMC/Ext/K", false},
+		{"/VMC/Ext/{T", "/VMC/Ext/T", false},
+		{"/a?b", "/a/b", false},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, osc.MatchAddress(tt.pattern, []byte(tt.address)), "%s %s", tt.pattern, tt.address)
+	}
+}
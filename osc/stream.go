@@ -0,0 +1,244 @@
+package osc
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Framing is a scheme to delimit OSC packets in a byte stream, like a TCP connection or a serial
+// link, as opposed to datagrams that already carry a single packet each.
+type Framing uint8
+
+// Supported framing schemes.
+const (
+	// FramingLengthPrefix prefixes each packet with its size as 32-bit big-endian integer, as
+	// specified by OSC 1.0.
+	FramingLengthPrefix Framing = iota
+	// FramingSLIP wraps each packet in END bytes and escapes them inside the packet, as specified
+	// by OSC 1.1 with SLIP (RFC 1055) in double-ended form.
+	FramingSLIP
+)
+
+var _ fmt.Stringer = (*Framing)(nil)
+
+func (f Framing) String() string {
+	switch f {
+	case FramingLengthPrefix:
+		return "LengthPrefix"
+	case FramingSLIP:
+		return "SLIP"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(f))
+	}
+}
+
+// DefaultMaxFrameSize is the size limit of frames, that is used if FrameReader.MaxSize is zero.
+const DefaultMaxFrameSize = 1 << 20
+
+// Special bytes of the SLIP framing.
+const (
+	slipEnd    = 0xc0
+	slipEsc    = 0xdb
+	slipEscEnd = 0xdc
+	slipEscEsc = 0xdd
+)
+
+// FrameTooLargeError occurs when a frame exceeds the size limit of the reader. The frame is
+// skipped, so reading can continue with the next one.
+type FrameTooLargeError struct {
+	Size  int // Size is the size of the frame, or the amount of bytes read until the limit was hit.
+	Limit int // Limit is the maximum allowed size.
+}
+
+var _ error = (*FrameTooLargeError)(nil)
+
+func (e FrameTooLargeError) Error() string {
+	return fmt.Sprintf("frame of %d bytes exceeds the limit of %d bytes", e.Size, e.Limit)
+}
+
+// FrameReader reads framed OSC packets from a byte stream. It must not be used concurrently.
+type FrameReader struct {
+	// MaxSize is the size limit of a single frame, to protect against broken or malicious peers.
+	// If zero, DefaultMaxFrameSize is used.
+	MaxSize int
+
+	r       *bufio.Reader
+	framing Framing
+	buf     []byte
+}
+
+// NewFrameReader creates a new reader, that reads frames of the given scheme from r.
+func NewFrameReader(r io.Reader, framing Framing) *FrameReader {
+	return &FrameReader{
+		MaxSize: 0,
+		r:       bufio.NewReader(r),
+		framing: framing,
+		buf:     nil,
+	}
+}
+
+// ReadFrame reads the next frame, which is only valid until the next call. It returns io.EOF if
+// the stream ended between frames, and io.ErrUnexpectedEOF if it ended inside a frame.
+func (f *FrameReader) ReadFrame() ([]byte, error) {
+	limit := f.MaxSize
+	if limit == 0 {
+		limit = DefaultMaxFrameSize
+	}
+
+	if f.framing == FramingSLIP {
+		return f.readSLIP(limit)
+	}
+
+	return f.readLengthPrefix(limit)
+}
+
+func (f *FrameReader) readLengthPrefix(limit int) ([]byte, error) {
+	var header [4]byte
+
+	if _, err := io.ReadFull(f.r, header[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		return nil, fmt.Errorf("failed reading frame size: %w", err)
+	}
+
+	size := int64(binary.BigEndian.Uint32(header[:]))
+	if size > int64(limit) {
+		if _, err := io.CopyN(io.Discard, f.r, size); err != nil {
+			return nil, fmt.Errorf("failed skipping frame: %w", unexpectedEOF(err))
+		}
+
+		return nil, FrameTooLargeError{Size: int(size), Limit: limit}
+	}
+
+	if cap(f.buf) < int(size) {
+		f.buf = make([]byte, size)
+	}
+
+	f.buf = f.buf[:size]
+
+	if _, err := io.ReadFull(f.r, f.buf); err != nil {
+		return nil, fmt.Errorf("failed reading frame: %w", unexpectedEOF(err))
+	}
+
+	return f.buf, nil
+}
+
+func (f *FrameReader) readSLIP(limit int) ([]byte, error) {
+	f.buf = f.buf[:0]
+	size := 0
+
+	for {
+		b, err := f.r.ReadByte()
+		if err != nil {
+			if size == 0 && errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+
+			return nil, fmt.Errorf("failed reading frame: %w", unexpectedEOF(err))
+		}
+
+		switch b {
+		case slipEnd:
+			// Empty frames occur between the END bytes of double-ended framing, and are skipped.
+			if size == 0 {
+				continue
+			}
+
+			if size > limit {
+				return nil, FrameTooLargeError{Size: size, Limit: limit}
+			}
+
+			return f.buf, nil
+		case slipEsc:
+			if b, err = f.r.ReadByte(); err != nil {
+				return nil, fmt.Errorf("failed reading frame: %w", unexpectedEOF(err))
+			}
+
+			// Other escaped bytes are a protocol violation, and kept as they are, following
+			// RFC 1055.
+			switch b {
+			case slipEscEnd:
+				b = slipEnd
+			case slipEscEsc:
+				b = slipEsc
+			}
+		}
+
+		// Oversized frames are read until their end, but not kept.
+		size++
+		if size <= limit {
+			f.buf = append(f.buf, b)
+		}
+	}
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF, for streams that end inside a frame.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
+
+// FrameWriter writes framed OSC packets to a byte stream. Each frame is passed to the underlying
+// writer with a single Write call. It must not be used concurrently.
+type FrameWriter struct {
+	w       io.Writer
+	framing Framing
+	buf     []byte
+}
+
+// NewFrameWriter creates a new writer, that writes frames of the given scheme to w.
+func NewFrameWriter(w io.Writer, framing Framing) *FrameWriter {
+	return &FrameWriter{
+		w:       w,
+		framing: framing,
+		buf:     nil,
+	}
+}
+
+// WriteFrame writes a single packet as frame.
+func (f *FrameWriter) WriteFrame(packet []byte) error {
+	if f.framing == FramingSLIP {
+		f.buf = AppendSLIPFrame(f.buf[:0], packet)
+	} else {
+		f.buf = AppendLengthPrefixFrame(f.buf[:0], packet)
+	}
+
+	if _, err := f.w.Write(f.buf); err != nil {
+		return fmt.Errorf("failed writing frame: %w", err)
+	}
+
+	return nil
+}
+
+// AppendLengthPrefixFrame appends the packet with its size prefix to the buffer.
+func AppendLengthPrefixFrame(buf, packet []byte) []byte {
+	buf = appendUint32(buf, uint32(len(packet)))
+
+	return append(buf, packet...)
+}
+
+// AppendSLIPFrame appends the packet in double-ended SLIP framing to the buffer.
+func AppendSLIPFrame(buf, packet []byte) []byte {
+	buf = append(buf, slipEnd)
+
+	for _, b := range packet {
+		switch b {
+		case slipEnd:
+			buf = append(buf, slipEsc, slipEscEnd)
+		case slipEsc:
+			buf = append(buf, slipEsc, slipEscEsc)
+		default:
+			buf = append(buf, b)
+		}
+	}
+
+	return append(buf, slipEnd)
+}
@@ -0,0 +1,113 @@
+package osc_test
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFramingPipe(t *testing.T) {
+	packets := [][]byte{
+		osc.AppendMessage(nil, "/a", []byte("i"), osc.AppendInt(nil, 1)),
+		// Contains both special SLIP bytes, which must be escaped.
+		osc.AppendMessage(nil, "/b", []byte("b"), osc.AppendBlob(nil, []byte{0xc0, 0xdb, 0xdc, 0xdd})),
+		osc.AppendElement(osc.AppendBundle(nil, osc.TimeTagImmediate), func(buf []byte) []byte {
+			return osc.AppendMessage(buf, "/c", nil, nil)
+		}),
+	}
+
+	for _, framing := range []osc.Framing{osc.FramingLengthPrefix, osc.FramingSLIP} {
+		framing := framing
+		t.Run(framing.String(), func(t *testing.T) {
+			client, server := net.Pipe()
+
+			go func() {
+				writer := osc.NewFrameWriter(client, framing)
+				for _, packet := range packets {
+					assert.NoError(t, writer.WriteFrame(packet))
+				}
+
+				client.Close()
+			}()
+
+			reader := osc.NewFrameReader(server, framing)
+
+			for _, packet := range packets {
+				frame, err := reader.ReadFrame()
+				require.NoError(t, err)
+				assert.Equal(t, packet, frame)
+
+				_, _, err = osc.ReadPacket(frame)
+				assert.NoError(t, err)
+			}
+
+			_, err := reader.ReadFrame()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestFramingEncoding(t *testing.T) {
+	assert.Equal(t, []byte{0, 0, 0, 3, 1, 2, 3}, osc.AppendLengthPrefixFrame(nil, []byte{1, 2, 3}))
+	assert.Equal(t, []byte{0xc0, 1, 0xdb, 0xdc, 0xdb, 0xdd, 0xc0}, osc.AppendSLIPFrame(nil, []byte{1, 0xc0, 0xdb}))
+	assert.Equal(t, "Unknown(5)", osc.Framing(5).String())
+}
+
+func TestFramingSLIPLenient(t *testing.T) {
+	// Repeated END bytes are skipped, and invalid escapes keep the escaped byte.
+	reader := osc.NewFrameReader(bytes.NewReader([]byte{0xc0, 0xc0, 1, 0xdb, 2, 0xc0}), osc.FramingSLIP)
+
+	frame, err := reader.ReadFrame()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, frame)
+
+	_, err = reader.ReadFrame()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestFramingErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		framing osc.Framing
+		input   []byte
+	}{
+		{"prefix truncated size", osc.FramingLengthPrefix, []byte{0, 0}},
+		{"prefix truncated frame", osc.FramingLengthPrefix, []byte{0, 0, 0, 4, 1}},
+		{"slip missing end", osc.FramingSLIP, []byte{0xc0, 1, 2}},
+		{"slip truncated escape", osc.FramingSLIP, []byte{0xc0, 1, 0xdb}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := osc.NewFrameReader(bytes.NewReader(tt.input), tt.framing).ReadFrame()
+			assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		})
+	}
+}
+
+func TestFramingMaxSize(t *testing.T) {
+	for _, framing := range []osc.Framing{osc.FramingLengthPrefix, osc.FramingSLIP} {
+		var stream bytes.Buffer
+
+		writer := osc.NewFrameWriter(&stream, framing)
+		require.NoError(t, writer.WriteFrame(make([]byte, 16)))
+		require.NoError(t, writer.WriteFrame([]byte{1, 2, 3, 4}))
+
+		reader := osc.NewFrameReader(&stream, framing)
+		reader.MaxSize = 8
+
+		_, err := reader.ReadFrame()
+		assert.ErrorAs(t, err, &osc.FrameTooLargeError{}, framing)
+
+		// The oversized frame is skipped, and reading continues with the next one.
+		frame, err := reader.ReadFrame()
+		require.NoError(t, err, framing)
+		assert.Equal(t, []byte{1, 2, 3, 4}, frame, framing)
+	}
+}
@@ -0,0 +1,50 @@
+package remap
+
+import "github.com/dnaka91/go-vmcparser/vmc"
+
+// ARKitVRM0 creates blend shape rules, that derive all VRM 0.x presets from ARKit blend shapes.
+// They're meant for perfect sync face trackers, driving avatars without ARKit blend shapes.
+func ARKitVRM0() []BlendShapeRule {
+	var rules []BlendShapeRule
+
+	for preset := vmc.VRM0Neutral; preset <= vmc.VRM0BlinkRight; preset++ {
+		rules = appendARKitRule(rules, preset.String(), preset.ARKit())
+	}
+
+	return rules
+}
+
+// ARKitVRM1 creates blend shape rules, that derive all VRM 1.0 expressions from ARKit blend
+// shapes.
+func ARKitVRM1() []BlendShapeRule {
+	var rules []BlendShapeRule
+
+	for expression := vmc.VRM1Neutral; expression <= vmc.VRM1Surprised; expression++ {
+		rules = appendARKitRule(rules, expression.String(), expression.ARKit())
+	}
+
+	return rules
+}
+
+func appendARKitRule(
+	rules []BlendShapeRule, name string, weights []vmc.ARKitWeight,
+) []BlendShapeRule {
+	if len(weights) == 0 {
+		return rules
+	}
+
+	sources := make([]Source, len(weights))
+	for i, weight := range weights {
+		sources[i] = Source{Name: weight.Shape.String(), Weight: weight.Weight}
+	}
+
+	return append(rules, BlendShapeRule{
+		To:      name,
+		From:    "",
+		Sources: sources,
+		Drop:    false,
+		Scale:   0,
+		Min:     0,
+		Max:     1,
+	})
+}
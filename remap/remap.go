@@ -0,0 +1,305 @@
+// Package remap renames, scales, combines and drops bones and blend shapes of VMC message streams.
+//
+// Avatar applications don't agree on the names of blend shapes, like the 52 ARKit shapes versus
+// the VRM presets, and some use non-standard bone names. A Remapper translates between them, based
+// on rules that are usually loaded from a JSON file:
+//
+//	{
+//	  "bones": [
+//	    {"from": "J_Bip_C_Hips", "to": "Hips"},
+//	    {"from": "Tail", "drop": true}
+//	  ],
+//	  "blendShapes": [
+//	    {"from": "eyeBlinkLeft", "to": "Blink_L"},
+//	    {
+//	      "to": "A",
+//	      "sources": [{"name": "jawOpen", "weight": 0.8}, {"name": "mouthFunnel", "weight": 0.4}],
+//	      "max": 1
+//	    },
+//	    {"from": "tongueOut", "drop": true}
+//	  ]
+//	}
+//
+// Blend shapes that are used as source of any rule are consumed, and the rule results are emitted
+// right before the BlendShapeProxyApply message, that completes the frame.
+package remap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+// ErrInvalidConfig is wrapped by all errors, that describe inconsistent rules.
+var ErrInvalidConfig = errors.New("invalid remap config")
+
+// Config contains all remapping rules.
+type Config struct {
+	Bones       []BoneRule       `json:"bones"`       // Bones are the rules for bone transforms.
+	BlendShapes []BlendShapeRule `json:"blendShapes"` // BlendShapes are the rules for blend shapes.
+	// DropUnmappedBones drops all bones, that aren't matched by any rule.
+	DropUnmappedBones bool `json:"dropUnmappedBones"`
+	// DropUnmappedBlendShapes drops all blend shapes, that aren't used by any rule.
+	DropUnmappedBlendShapes bool `json:"dropUnmappedBlendShapes"`
+}
+
+// BoneRule renames, scales or drops a single bone.
+type BoneRule struct {
+	From string `json:"from"` // From is the name of the incoming bone.
+	To   string `json:"to"`   // To is the new name. If empty, the name is kept.
+	Drop bool   `json:"drop"` // Drop removes the bone from the stream.
+	// Scale multiplies the bone position, to adjust for different avatar sizes. If zero, the
+	// position is kept as is.
+	Scale float32 `json:"scale"`
+}
+
+// BlendShapeRule creates a blend shape from one or more incoming blend shapes, or drops one.
+//
+// The value is calculated as weighted sum of all sources, multiplied by the scale. If Max is
+// greater than Min, the result is clamped to that range.
+type BlendShapeRule struct {
+	To      string   `json:"to"`      // To is the name of the resulting blend shape.
+	From    string   `json:"from"`    // From is a single source with a weight of 1.
+	Sources []Source `json:"sources"` // Sources are multiple weighted sources.
+	Drop    bool     `json:"drop"`    // Drop removes the From blend shape from the stream.
+	Scale   float32  `json:"scale"`   // Scale multiplies the result. If zero, it's 1.
+	Min     float32  `json:"min"`     // Min is the lower bound of the result.
+	Max     float32  `json:"max"`     // Max is the upper bound of the result.
+}
+
+// Source is a weighted source of a BlendShapeRule.
+type Source struct {
+	Name   string  `json:"name"`   // Name is the name of the incoming blend shape.
+	Weight float32 `json:"weight"` // Weight is the factor of the blend shape value.
+}
+
+// Remapper applies the rules to a message stream. It must not be used concurrently.
+type Remapper struct {
+	config      Config
+	bones       map[string]*bone
+	blendShapes []blendShape
+	sources     map[string][]sourceRef
+	dropped     map[string]struct{}
+	scratch     vmc.BlendShapeProxyValue
+}
+
+type bone struct {
+	name  []byte
+	drop  bool
+	scale float32
+}
+
+// blendShape is the state of a single blend shape rule.
+type blendShape struct {
+	name    []byte
+	weights []float32
+	values  []float32
+	scale   float32
+	min     float32
+	max     float32
+	dirty   bool
+}
+
+// sourceRef locates a source within the blend shape rules.
+type sourceRef struct {
+	rule   int
+	source int
+}
+
+// Load reads the rules as JSON and creates a remapper from them.
+func Load(r io.Reader) (*Remapper, error) {
+	var config Config
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed decoding remap config: %w", err)
+	}
+
+	return New(config)
+}
+
+// New validates the rules and creates a remapper from them.
+func New(config Config) (*Remapper, error) {
+	r := &Remapper{
+		config:      config,
+		bones:       make(map[string]*bone, len(config.Bones)),
+		blendShapes: make([]blendShape, 0, len(config.BlendShapes)),
+		sources:     make(map[string][]sourceRef),
+		dropped:     make(map[string]struct{}),
+		scratch:     vmc.BlendShapeProxyValue{Name: nil, Value: 0},
+	}
+
+	for i := range config.Bones {
+		if err := r.addBone(&config.Bones[i]); err != nil {
+			return nil, fmt.Errorf("bone rule %d: %w", i, err)
+		}
+	}
+
+	for i := range config.BlendShapes {
+		if err := r.addBlendShape(&config.BlendShapes[i]); err != nil {
+			return nil, fmt.Errorf("blend shape rule %d: %w", i, err)
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Remapper) addBone(rule *BoneRule) error {
+	if rule.From == "" {
+		return fmt.Errorf("%w: missing source name", ErrInvalidConfig)
+	}
+
+	if _, ok := r.bones[rule.From]; ok {
+		return fmt.Errorf("%w: duplicate rule for %s", ErrInvalidConfig, rule.From)
+	}
+
+	if rule.Drop && (rule.To != "" || rule.Scale != 0) {
+		return fmt.Errorf("%w: dropped bone %s can't be renamed or scaled", ErrInvalidConfig, rule.From)
+	}
+
+	name := rule.To
+	if name == "" {
+		name = rule.From
+	}
+
+	r.bones[rule.From] = &bone{name: []byte(name), drop: rule.Drop, scale: rule.Scale}
+
+	return nil
+}
+
+func (r *Remapper) addBlendShape(rule *BlendShapeRule) error {
+	if rule.Drop {
+		if rule.From == "" || rule.To != "" || len(rule.Sources) > 0 {
+			return fmt.Errorf("%w: drop rules need a source name and nothing else", ErrInvalidConfig)
+		}
+
+		r.dropped[rule.From] = struct{}{}
+
+		return nil
+	}
+
+	if rule.To == "" {
+		return fmt.Errorf("%w: missing target name", ErrInvalidConfig)
+	}
+
+	sources := rule.Sources
+	if rule.From != "" {
+		if len(sources) > 0 {
+			return fmt.Errorf("%w: %s has both a single and multiple sources", ErrInvalidConfig, rule.To)
+		}
+
+		sources = []Source{{Name: rule.From, Weight: 1}}
+	}
+
+	if len(sources) == 0 {
+		return fmt.Errorf("%w: %s has no sources", ErrInvalidConfig, rule.To)
+	}
+
+	if rule.Max < rule.Min {
+		return fmt.Errorf("%w: %s has a maximum below its minimum", ErrInvalidConfig, rule.To)
+	}
+
+	state := blendShape{
+		name:    []byte(rule.To),
+		weights: make([]float32, len(sources)),
+		values:  make([]float32, len(sources)),
+		scale:   rule.Scale,
+		min:     rule.Min,
+		max:     rule.Max,
+		dirty:   false,
+	}
+
+	if state.scale == 0 {
+		state.scale = 1
+	}
+
+	for i, source := range sources {
+		if source.Name == "" {
+			return fmt.Errorf("%w: %s has a source without name", ErrInvalidConfig, rule.To)
+		}
+
+		state.weights[i] = source.Weight
+		ref := sourceRef{rule: len(r.blendShapes), source: i}
+		r.sources[source.Name] = append(r.sources[source.Name], ref)
+	}
+
+	r.blendShapes = append(r.blendShapes, state)
+
+	return nil
+}
+
+// Process applies the rules to the message, and emits the resulting messages. Messages may be
+// modified in place, dropped, or additional messages may be emitted. Messages without bones or
+// blend shapes are passed through as is.
+//
+// Emitted messages are only valid during the callback, as they're reused.
+func (r *Remapper) Process(msg vmc.Message, emit func(msg vmc.Message)) {
+	switch m := msg.(type) {
+	case *vmc.BoneTransform:
+		rule, ok := r.bones[string(m.Name)]
+
+		switch {
+		case !ok && r.config.DropUnmappedBones, ok && rule.drop:
+			return
+		case ok:
+			m.Name = rule.name
+
+			if rule.scale != 0 {
+				m.Position = m.Position.Scale(rule.scale)
+			}
+		}
+	case *vmc.BlendShapeProxyValue:
+		if refs, ok := r.sources[string(m.Name)]; ok {
+			for _, ref := range refs {
+				r.blendShapes[ref.rule].values[ref.source] = m.Value
+				r.blendShapes[ref.rule].dirty = true
+			}
+
+			return
+		}
+
+		if _, ok := r.dropped[string(m.Name)]; ok || r.config.DropUnmappedBlendShapes {
+			return
+		}
+	case *vmc.BlendShapeProxyApply:
+		r.flush(emit)
+	}
+
+	emit(msg)
+}
+
+// flush emits all blend shapes, that had any of their sources updated.
+func (r *Remapper) flush(emit func(msg vmc.Message)) {
+	for i := range r.blendShapes {
+		state := &r.blendShapes[i]
+		if !state.dirty {
+			continue
+		}
+
+		state.dirty = false
+
+		var value float32
+		for j, weight := range state.weights {
+			value += weight * state.values[j]
+		}
+
+		value *= state.scale
+
+		if state.max > state.min {
+			switch {
+			case value < state.min:
+				value = state.min
+			case value > state.max:
+				value = state.max
+			}
+		}
+
+		r.scratch = vmc.BlendShapeProxyValue{Name: state.name, Value: value}
+		emit(&r.scratch)
+	}
+}
@@ -0,0 +1,184 @@
+package remap_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/remap"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collect runs the messages through the remapper, and returns a copy of all emitted messages.
+func collect(r *remap.Remapper, msgs ...vmc.Message) []vmc.Message {
+	var out []vmc.Message
+
+	for _, msg := range msgs {
+		r.Process(msg, func(msg vmc.Message) {
+			switch m := msg.(type) {
+			case *vmc.BlendShapeProxyValue:
+				out = append(out, &vmc.BlendShapeProxyValue{Name: []byte(string(m.Name)), Value: m.Value})
+			case *vmc.BoneTransform:
+				out = append(out, &vmc.BoneTransform{Name: []byte(string(m.Name)), Position: m.Position, Quaternion: m.Quaternion})
+			default:
+				out = append(out, msg)
+			}
+		})
+	}
+
+	return out
+}
+
+func load(t *testing.T) *remap.Remapper {
+	t.Helper()
+
+	file, err := os.Open("testdata/rules.json")
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	r, err := remap.Load(file)
+	require.NoError(t, err)
+
+	return r
+}
+
+func bone(name string, y float32) *vmc.BoneTransform {
+	return &vmc.BoneTransform{Name: []byte(name), Position: vmc.Vec3{Y: y}, Quaternion: vmc.Vec4{W: 1}}
+}
+
+func blend(name string, value float32) *vmc.BlendShapeProxyValue {
+	return &vmc.BlendShapeProxyValue{Name: []byte(name), Value: value}
+}
+
+func TestBones(t *testing.T) {
+	r := load(t)
+
+	out := collect(r,
+		bone("J_Bip_C_Hips", 1),
+		bone("J_Bip_C_Spine", 1),
+		bone("Tail", 1),
+		bone("Head", 1),
+		&vmc.RelativeTime{Time: 1},
+	)
+
+	assert.Equal(t, []vmc.Message{
+		bone("Hips", 1),
+		bone("Spine", 2),
+		bone("Head", 1),
+		&vmc.RelativeTime{Time: 1},
+	}, out)
+}
+
+func TestBlendShapes(t *testing.T) {
+	r := load(t)
+
+	out := collect(r,
+		blend("eyeBlinkLeft", 0.5),
+		blend("jawOpen", 1),
+		blend("mouthFunnel", 1),
+		blend("mouthSmileLeft", 0.5),
+		blend("tongueOut", 1),
+		blend("Custom", 0.25),
+		&vmc.BlendShapeProxyApply{},
+	)
+
+	assert.Equal(t, []vmc.Message{
+		blend("Custom", 0.25),
+		blend("Blink_L", 0.5),
+		blend("A", 1),
+		blend("Joy", 0.25),
+		&vmc.BlendShapeProxyApply{},
+	}, out)
+
+	// Only rules with updated sources are emitted, and combinations use the last known values.
+	out = collect(r,
+		blend("mouthFunnel", 0),
+		&vmc.BlendShapeProxyApply{},
+	)
+
+	assert.Equal(t, []vmc.Message{
+		blend("A", 0.8),
+		&vmc.BlendShapeProxyApply{},
+	}, out)
+}
+
+func TestDropUnmapped(t *testing.T) {
+	r, err := remap.New(remap.Config{
+		Bones:                   []remap.BoneRule{{From: "Hips"}},
+		BlendShapes:             []remap.BlendShapeRule{{From: "A", To: "A"}},
+		DropUnmappedBones:       true,
+		DropUnmappedBlendShapes: true,
+	})
+	require.NoError(t, err)
+
+	out := collect(r,
+		bone("Hips", 1),
+		bone("Head", 1),
+		blend("A", 1),
+		blend("B", 1),
+		&vmc.BlendShapeProxyApply{},
+	)
+
+	assert.Equal(t, []vmc.Message{
+		bone("Hips", 1),
+		blend("A", 1),
+		&vmc.BlendShapeProxyApply{},
+	}, out)
+}
+
+func TestInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{"bone without source", `{"bones": [{"to": "Hips"}]}`},
+		{"duplicate bone", `{"bones": [{"from": "A"}, {"from": "A"}]}`},
+		{"renamed drop", `{"bones": [{"from": "A", "to": "B", "drop": true}]}`},
+		{"blend shape without target", `{"blendShapes": [{"from": "A"}]}`},
+		{"blend shape without source", `{"blendShapes": [{"to": "A"}]}`},
+		{"both sources", `{"blendShapes": [{"to": "A", "from": "B", "sources": [{"name": "C"}]}]}`},
+		{"unnamed source", `{"blendShapes": [{"to": "A", "sources": [{"weight": 1}]}]}`},
+		{"inverted range", `{"blendShapes": [{"to": "A", "from": "B", "min": 1, "max": 0}]}`},
+		{"drop with target", `{"blendShapes": [{"from": "A", "to": "B", "drop": true}]}`},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := remap.Load(strings.NewReader(tt.config))
+			assert.ErrorIs(t, err, remap.ErrInvalidConfig)
+		})
+	}
+
+	_, err := remap.Load(strings.NewReader(`{"unknown": true}`))
+	assert.Error(t, err)
+}
+
+func TestARKit(t *testing.T) {
+	r, err := remap.New(remap.Config{BlendShapes: remap.ARKitVRM0(), DropUnmappedBlendShapes: true})
+	require.NoError(t, err)
+
+	out := collect(r,
+		blend("eyeBlinkLeft", 1),
+		blend("jawOpen", 1),
+		blend("tongueOut", 1),
+		&vmc.BlendShapeProxyApply{},
+	)
+
+	assert.Equal(t, []vmc.Message{
+		blend("A", 1),
+		blend("I", 0.2),
+		blend("U", 0.2),
+		blend("E", 0.4),
+		blend("O", 0.3),
+		blend("Blink", 0.5),
+		blend("Blink_L", 1),
+		&vmc.BlendShapeProxyApply{},
+	}, out)
+
+	_, err = remap.New(remap.Config{BlendShapes: remap.ARKitVRM1()})
+	require.NoError(t, err)
+}
@@ -0,0 +1,306 @@
+// Package resample turns avatar frames, that arrive at varying rates, into a steady stream of
+// interpolated frames.
+//
+// Incoming frames are buffered with a timestamp, and the output runs behind the input by a fixed
+// latency. That way, there are usually two frames around each output time, which are interpolated:
+// positions and blend shapes linearly and rotations spherically.
+package resample
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+// Default values, for unset fields of the Config.
+const (
+	DefaultRate     = 60
+	DefaultCapacity = 64
+)
+
+// maxDrift is the maximum difference between the sender time and the arrival time of a frame,
+// before the sender time is synchronized again.
+const maxDrift = time.Second
+
+// Clock selects the timestamps of incoming frames.
+type Clock uint8
+
+// Possible clocks.
+const (
+	// ClockArrival times frames by their arrival. It works with any sender, but network jitter
+	// becomes visible in the output.
+	ClockArrival Clock = iota
+	// ClockRelativeTime times frames by the RelativeTime messages of the sender, which is free from
+	// network jitter. The sender time is mapped to the arrival time of the first frame, and
+	// synchronized again if the sender restarts or drifts away.
+	ClockRelativeTime
+)
+
+func (c Clock) String() string {
+	switch c {
+	case ClockArrival:
+		return "Arrival"
+	case ClockRelativeTime:
+		return "RelativeTime"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(c))
+	}
+}
+
+// Config defines the output of a Resampler.
+type Config struct {
+	// Rate is the output rate in frames per second. If zero, DefaultRate is used.
+	Rate float64
+	// Latency is the delay of the output behind the input. It should cover at least one interval
+	// of the input rate plus the expected network jitter, as the last frame is held if no newer
+	// frame is available.
+	Latency time.Duration
+	// Clock selects the timestamps of incoming frames.
+	Clock Clock
+	// Capacity is the maximum amount of buffered frames. If exceeded, the oldest frames are
+	// dropped. If zero, DefaultCapacity is used.
+	Capacity int
+}
+
+type timedFrame struct {
+	at    time.Time
+	frame vmc.Frame
+}
+
+// Resampler buffers incoming frames and creates interpolated frames at a fixed rate. It must not
+// be used concurrently.
+type Resampler struct {
+	config   Config
+	interval time.Duration
+	builder  vmc.FrameBuilder
+
+	frames []timedFrame
+	spare  []vmc.Frame
+
+	base     time.Time
+	lastTime float32
+	synced   bool
+
+	next   time.Time
+	output vmc.Frame
+}
+
+// New creates a new resampler with the given configuration.
+func New(config Config) *Resampler {
+	if config.Rate <= 0 {
+		config.Rate = DefaultRate
+	}
+
+	if config.Capacity <= 0 {
+		config.Capacity = DefaultCapacity
+	}
+
+	return &Resampler{
+		config:   config,
+		interval: time.Duration(float64(time.Second) / config.Rate),
+		builder:  vmc.FrameBuilder{},
+		frames:   make([]timedFrame, 0, config.Capacity+1),
+		spare:    nil,
+		base:     time.Time{},
+		lastTime: 0,
+		synced:   false,
+		next:     time.Time{},
+		output:   vmc.Frame{Time: 0, RootName: "", Root: vmc.Pose{}, Bones: nil, BlendShapes: nil},
+	}
+}
+
+// Interval is the time between two output frames.
+func (r *Resampler) Interval() time.Duration {
+	return r.interval
+}
+
+// Add collects the message into the frame under construction, which is buffered once completed.
+// The time is the arrival time of the message.
+func (r *Resampler) Add(msg vmc.Message, at time.Time) {
+	if r.builder.Add(msg) {
+		r.AddFrame(r.builder.Frame(), at)
+	}
+}
+
+// AddFrame buffers a copy of the frame. The time is the arrival time of the frame. Frames that are
+// older than the newest buffered frame are dropped.
+func (r *Resampler) AddFrame(frame *vmc.Frame, at time.Time) {
+	if r.config.Clock == ClockRelativeTime {
+		at = r.senderTime(frame.Time, at)
+	}
+
+	if n := len(r.frames); n > 0 && !at.After(r.frames[n-1].at) {
+		return
+	}
+
+	var stored vmc.Frame
+
+	if n := len(r.spare); n > 0 {
+		stored = r.spare[n-1]
+		r.spare = r.spare[:n-1]
+	}
+
+	copyFrame(&stored, frame)
+	r.frames = append(r.frames, timedFrame{at: at, frame: stored})
+
+	if len(r.frames) > r.config.Capacity {
+		r.drop(len(r.frames) - r.config.Capacity)
+	}
+}
+
+// senderTime maps the relative time of the sender to the local clock.
+func (r *Resampler) senderTime(relative float32, arrival time.Time) time.Time {
+	offset := time.Duration(float64(relative) * float64(time.Second))
+	at := r.base.Add(offset)
+
+	if !r.synced || relative < r.lastTime || at.Sub(arrival) > maxDrift || arrival.Sub(at) > maxDrift {
+		r.base = arrival.Add(-offset)
+		r.synced = true
+		at = arrival
+	}
+
+	r.lastTime = relative
+
+	return at
+}
+
+// Next creates the next output frame, once its time has come. It returns false if it's too early,
+// or no frames were received yet. It's meant to be called regularly, like from a time.Ticker with
+// the Interval. If the calls fall behind by more than one interval, the missed frames are skipped.
+//
+// The returned frame is owned by the resampler and only valid until the next call to Next.
+func (r *Resampler) Next(now time.Time) (*vmc.Frame, bool) {
+	if len(r.frames) == 0 {
+		return nil, false
+	}
+
+	if r.next.IsZero() {
+		r.next = now
+	}
+
+	if now.Before(r.next) {
+		return nil, false
+	}
+
+	if now.Sub(r.next) >= r.interval {
+		r.next = now
+	}
+
+	r.sample(r.next.Add(-r.config.Latency))
+	r.next = r.next.Add(r.interval)
+
+	return &r.output, true
+}
+
+// Reset drops all buffered frames and restarts the output timing.
+func (r *Resampler) Reset() {
+	r.drop(len(r.frames))
+	r.synced = false
+	r.next = time.Time{}
+}
+
+// sample interpolates the output frame at the given playback time.
+func (r *Resampler) sample(at time.Time) {
+	index := 0
+
+	for index < len(r.frames)-1 && !r.frames[index+1].at.After(at) {
+		index++
+	}
+
+	// Frames before the current one are never needed again, as time only moves forward.
+	r.drop(index)
+
+	current := &r.frames[0]
+	if len(r.frames) == 1 || !at.After(current.at) {
+		copyFrame(&r.output, &current.frame)
+
+		return
+	}
+
+	next := &r.frames[1]
+	t := float32(at.Sub(current.at)) / float32(next.at.Sub(current.at))
+
+	interpolate(&r.output, &current.frame, &next.frame, t)
+}
+
+// drop removes the oldest frames from the buffer, keeping them for reuse.
+func (r *Resampler) drop(count int) {
+	if count == 0 {
+		return
+	}
+
+	for i := 0; i < count; i++ {
+		r.spare = append(r.spare, r.frames[i].frame)
+	}
+
+	n := copy(r.frames, r.frames[count:])
+	r.frames = r.frames[:n]
+}
+
+// copyFrame copies the source frame into the destination, reusing its maps.
+func copyFrame(dst, src *vmc.Frame) {
+	dst.Time = src.Time
+	dst.RootName = src.RootName
+	dst.Root = src.Root
+	dst.Bones = clearMap(dst.Bones)
+	dst.BlendShapes = clearMap(dst.BlendShapes)
+
+	for name, pose := range src.Bones {
+		dst.Bones[name] = pose
+	}
+
+	for name, value := range src.BlendShapes {
+		dst.BlendShapes[name] = value
+	}
+}
+
+// interpolate blends the frames a and b into dst. Bones and blend shapes, that are only part of
+// one of the frames, are taken as is.
+func interpolate(dst, a, b *vmc.Frame, t float32) {
+	dst.Time = a.Time + (b.Time-a.Time)*t
+	dst.RootName = b.RootName
+	dst.Root = a.Root.Interpolate(b.Root, t)
+	dst.Bones = clearMap(dst.Bones)
+	dst.BlendShapes = clearMap(dst.BlendShapes)
+
+	for name, pose := range a.Bones {
+		if other, ok := b.Bones[name]; ok {
+			pose = pose.Interpolate(other, t)
+		}
+
+		dst.Bones[name] = pose
+	}
+
+	for name, pose := range b.Bones {
+		if _, ok := a.Bones[name]; !ok {
+			dst.Bones[name] = pose
+		}
+	}
+
+	for name, value := range a.BlendShapes {
+		if other, ok := b.BlendShapes[name]; ok {
+			value += (other - value) * t
+		}
+
+		dst.BlendShapes[name] = value
+	}
+
+	for name, value := range b.BlendShapes {
+		if _, ok := a.BlendShapes[name]; !ok {
+			dst.BlendShapes[name] = value
+		}
+	}
+}
+
+func clearMap[V any](m map[string]V) map[string]V {
+	if m == nil {
+		return make(map[string]V)
+	}
+
+	for key := range m {
+		delete(m, key)
+	}
+
+	return m
+}
@@ -0,0 +1,137 @@
+package resample_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/resample"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func frame(time, x, blend float32) *vmc.Frame {
+	return &vmc.Frame{
+		Time: time,
+		Root: vmc.Pose{Position: vmc.Vec3{X: x}, Quaternion: vmc.Vec4{W: 1}},
+		Bones: map[string]vmc.Pose{
+			"Hips": {Position: vmc.Vec3{Y: x}, Quaternion: vmc.Vec4{W: 1}},
+		},
+		BlendShapes: map[string]float32{"A": blend},
+	}
+}
+
+func TestInterpolation(t *testing.T) {
+	start := time.Now()
+	r := resample.New(resample.Config{Rate: 60, Latency: 50 * time.Millisecond})
+
+	r.AddFrame(frame(0, 0, 0), start)
+	r.AddFrame(frame(0, 10, 1), start.Add(100*time.Millisecond))
+
+	// Before the first frame, it's held.
+	out, ok := r.Next(start)
+	require.True(t, ok)
+	assert.Equal(t, float32(0), out.Root.Position.X)
+
+	out, ok = r.Next(start.Add(100 * time.Millisecond))
+	require.True(t, ok)
+	assert.InDelta(t, 5, out.Root.Position.X, 1e-4)
+	assert.InDelta(t, 5, out.Bones["Hips"].Position.Y, 1e-4)
+	assert.InDelta(t, 0.5, out.BlendShapes["A"], 1e-5)
+	assert.Equal(t, vmc.Vec4{W: 1}, out.Root.Quaternion)
+
+	// After the last frame, it's held as well.
+	out, ok = r.Next(start.Add(time.Second))
+	require.True(t, ok)
+	assert.Equal(t, float32(10), out.Root.Position.X)
+}
+
+func TestNextTiming(t *testing.T) {
+	start := time.Now()
+	r := resample.New(resample.Config{Rate: 50})
+
+	_, ok := r.Next(start)
+	assert.False(t, ok, "no frames yet")
+
+	r.AddFrame(frame(0, 0, 0), start)
+
+	_, ok = r.Next(start)
+	assert.True(t, ok)
+
+	_, ok = r.Next(start.Add(10 * time.Millisecond))
+	assert.False(t, ok)
+
+	_, ok = r.Next(start.Add(20 * time.Millisecond))
+	assert.True(t, ok)
+
+	// Falling behind skips the missed frames.
+	_, ok = r.Next(start.Add(200 * time.Millisecond))
+	assert.True(t, ok)
+
+	_, ok = r.Next(start.Add(210 * time.Millisecond))
+	assert.False(t, ok)
+
+	assert.Equal(t, 20*time.Millisecond, r.Interval())
+}
+
+func TestRelativeTimeClock(t *testing.T) {
+	start := time.Now()
+	r := resample.New(resample.Config{Rate: 60, Latency: 100 * time.Millisecond, Clock: resample.ClockRelativeTime})
+
+	// The second frame arrives late, but the sender time places it correctly.
+	r.AddFrame(frame(10, 0, 0), start)
+	r.AddFrame(frame(10.1, 10, 0), start.Add(180*time.Millisecond))
+
+	out, ok := r.Next(start.Add(150 * time.Millisecond))
+	require.True(t, ok)
+	assert.InDelta(t, 5, out.Root.Position.X, 1e-3)
+	assert.InDelta(t, 10.05, out.Time, 1e-4)
+}
+
+func TestMessages(t *testing.T) {
+	start := time.Now()
+	r := resample.New(resample.Config{})
+
+	r.Add(&vmc.BoneTransform{Name: []byte("Head"), Position: vmc.Vec3{Z: 2}, Quaternion: vmc.Vec4{W: 1}}, start)
+
+	_, ok := r.Next(start)
+	assert.False(t, ok, "frame isn't complete yet")
+
+	r.Add(&vmc.BlendShapeProxyApply{}, start)
+
+	out, ok := r.Next(start)
+	require.True(t, ok)
+	assert.Equal(t, float32(2), out.Bones["Head"].Position.Z)
+}
+
+func TestOutOfOrderAndCapacity(t *testing.T) {
+	start := time.Now()
+	r := resample.New(resample.Config{Capacity: 2})
+
+	r.AddFrame(frame(0, 1, 0), start.Add(10*time.Millisecond))
+	r.AddFrame(frame(0, 2, 0), start)
+	r.AddFrame(frame(0, 3, 0), start.Add(20*time.Millisecond))
+	r.AddFrame(frame(0, 4, 0), start.Add(30*time.Millisecond))
+
+	// The first frame was dropped due to the capacity, the second for being out of order.
+	out, ok := r.Next(start)
+	require.True(t, ok)
+	assert.Equal(t, float32(3), out.Root.Position.X)
+}
+
+func TestReset(t *testing.T) {
+	start := time.Now()
+	r := resample.New(resample.Config{})
+
+	r.AddFrame(frame(0, 1, 0), start)
+	r.Reset()
+
+	_, ok := r.Next(start)
+	assert.False(t, ok)
+
+	r.AddFrame(frame(0, 2, 0), start)
+
+	out, ok := r.Next(start)
+	require.True(t, ok)
+	assert.Equal(t, float32(2), out.Root.Position.X)
+}
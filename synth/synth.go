@@ -0,0 +1,319 @@
+// Package synth generates synthetic VMC motion streams, to test receivers without a running VMC
+// application.
+//
+// A Generator animates an avatar with a set of simple, but natural looking motions:
+//
+//   - Idle breathing, that slowly bends the spine and lifts the hips.
+//   - Head turns from side to side.
+//   - A waving right arm.
+//   - Blinking in slightly irregular intervals.
+//   - Lip sync, that follows a looped sequence of phonemes.
+//
+// The motions are calculated from the elapsed time only, so the same configuration always results
+// in the same stream, which makes it suitable for tests.
+package synth
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+// Default values, that are used for zero or negative values in the Config.
+const (
+	DefaultRate            = 60
+	DefaultBlinkInterval   = 4 * time.Second
+	DefaultPhonemeDuration = 150 * time.Millisecond
+)
+
+// Durations of the blink animation.
+const (
+	blinkClose = 60 * time.Millisecond
+	blinkOpen  = 90 * time.Millisecond
+)
+
+// Motion is a set of motions, that the generator animates.
+type Motion uint8
+
+// Possible motions, that can be combined.
+const (
+	MotionBreathing Motion = 1 << iota
+	MotionHeadTurn
+	MotionWave
+	MotionBlink
+	MotionLipSync
+
+	// MotionAll combines all motions.
+	MotionAll = MotionBreathing | MotionHeadTurn | MotionWave | MotionBlink | MotionLipSync
+)
+
+// Config configures the generated stream.
+type Config struct {
+	// Rate is the amount of frames per second. If zero, negative or not finite, DefaultRate is used.
+	Rate float64
+	// Motions are the enabled motions. If zero, MotionAll is used.
+	Motions Motion
+	// BlinkInterval is the average time between two blinks. If zero or negative,
+	// DefaultBlinkInterval is used.
+	BlinkInterval time.Duration
+	// Phonemes is the sequence of vowels for the lip sync, that is repeated endlessly. It consists
+	// of the letters a, i, u, e and o, while any other character is a pause. If empty, the mouth
+	// stays closed.
+	Phonemes string
+	// PhonemeDuration is the time of each phoneme. If zero or negative, DefaultPhonemeDuration is
+	// used.
+	PhonemeDuration time.Duration
+	// Seed initializes the randomness of the blink intervals.
+	Seed int64
+}
+
+// Generator creates the frames of a synthetic stream. It must not be used concurrently.
+type Generator struct {
+	config    Config
+	random    *rand.Rand
+	frame     vmc.Frame
+	lastBlink time.Duration
+	nextBlink time.Duration
+	buf       []byte
+}
+
+// New creates a new generator with the given configuration.
+func New(config Config) *Generator {
+	// Written as negation, so that NaN falls back to the default as well.
+	if !(config.Rate > 0) || math.IsInf(config.Rate, 1) {
+		config.Rate = DefaultRate
+	}
+
+	if config.Motions == 0 {
+		config.Motions = MotionAll
+	}
+
+	if config.BlinkInterval <= 0 {
+		config.BlinkInterval = DefaultBlinkInterval
+	}
+
+	if config.PhonemeDuration <= 0 {
+		config.PhonemeDuration = DefaultPhonemeDuration
+	}
+
+	g := &Generator{
+		config: config,
+		random: nil,
+		frame: vmc.Frame{
+			Time:        0,
+			RootName:    "root",
+			Root:        vmc.Pose{Position: vmc.Vec3{}, Quaternion: identity()},
+			Bones:       make(map[string]vmc.Pose),
+			BlendShapes: make(map[string]float32),
+		},
+		lastBlink: 0,
+		nextBlink: 0,
+		buf:       nil,
+	}
+	g.Reset()
+
+	return g
+}
+
+// Interval returns the time between two frames. It's at least a nanosecond, even for rates above
+// a billion frames per second.
+func (g *Generator) Interval() time.Duration {
+	if interval := time.Duration(float64(time.Second) / g.config.Rate); interval > 0 {
+		return interval
+	}
+
+	return 1
+}
+
+// Reset restarts the stream, including the random blink intervals.
+func (g *Generator) Reset() {
+	// The randomness only needs to look natural, and must be reproducible.
+	g.random = rand.New(rand.NewSource(g.config.Seed))
+	g.lastBlink = -time.Hour
+	g.nextBlink = g.blinkDelay()
+}
+
+// Frame calculates the frame at the elapsed time since the start of the stream. The elapsed time
+// must not decrease between calls, unless the generator is reset.
+//
+// The returned frame is owned by the generator, and only valid until the next call.
+func (g *Generator) Frame(elapsed time.Duration) *vmc.Frame {
+	seconds := elapsed.Seconds()
+
+	g.frame.Time = float32(seconds)
+	g.frame.Bones["Hips"] = vmc.Pose{Position: vmc.Vec3{X: 0, Y: 1, Z: 0}, Quaternion: identity()}
+
+	if g.config.Motions&MotionBreathing != 0 {
+		g.breathe(seconds)
+	}
+
+	if g.config.Motions&MotionHeadTurn != 0 {
+		g.turnHead(seconds)
+	}
+
+	if g.config.Motions&MotionWave != 0 {
+		g.wave(seconds)
+	}
+
+	if g.config.Motions&MotionBlink != 0 {
+		g.frame.BlendShapes[vmc.VRM0Blink.String()] = g.blink(elapsed)
+	}
+
+	if g.config.Motions&MotionLipSync != 0 {
+		g.lipSync(elapsed)
+	}
+
+	return &g.frame
+}
+
+// Messages emits the messages of the frame at the elapsed time. See Frame for details.
+//
+// The emitted messages are only valid during the callback, as they're reused between calls.
+func (g *Generator) Messages(elapsed time.Duration, emit func(msg vmc.Encodable)) {
+	emit(&vmc.Available{
+		Loaded:           true,
+		CalibrationState: vmc.Some(vmc.CalibrationStateCalibrated),
+		CalibrationMode:  vmc.Some(vmc.CalibrationModeNormal),
+		TrackingStatus:   vmc.Some(true),
+		Version:          vmc.ProtocolV2_7,
+	})
+	g.Frame(elapsed).Messages(emit)
+}
+
+// AppendBundle appends a single OSC bundle with all messages of the frame at the elapsed time to
+// the buffer.
+func (g *Generator) AppendBundle(buf []byte, elapsed time.Duration) []byte {
+	buf = osc.AppendBundle(buf, osc.TimeTagImmediate)
+
+	g.Messages(elapsed, func(msg vmc.Encodable) {
+		buf = osc.AppendElement(buf, msg.AppendMessage)
+	})
+
+	return buf
+}
+
+// Bundle returns the OSC bundle of the frame at the elapsed time. The bundle is owned by the
+// generator, and only valid until the next call.
+func (g *Generator) Bundle(elapsed time.Duration) []byte {
+	g.buf = g.AppendBundle(g.buf[:0], elapsed)
+
+	return g.buf
+}
+
+// breathe bends the spine and chest back and forth, while lifting the hips slightly.
+func (g *Generator) breathe(seconds float64) {
+	const (
+		frequency = 0.25
+		amplitude = 2
+		lift      = 0.005
+	)
+
+	cycle := math.Sin(2 * math.Pi * frequency * seconds)
+
+	hips := vmc.Vec3{X: 0, Y: 1 + float32(lift*cycle), Z: 0}
+
+	g.frame.Bones["Hips"] = vmc.Pose{Position: hips, Quaternion: identity()}
+	sway := float32(amplitude * cycle)
+
+	g.frame.Bones["Spine"] = pose(vmc.AxisAngle(vmc.Vec3{X: 1, Y: 0, Z: 0}, sway))
+	g.frame.Bones["Chest"] = pose(vmc.AxisAngle(vmc.Vec3{X: 1, Y: 0, Z: 0}, -sway))
+}
+
+// turnHead looks from side to side, where the neck takes a third of the rotation.
+func (g *Generator) turnHead(seconds float64) {
+	const (
+		period    = 6
+		amplitude = 30
+	)
+
+	yaw := float32(amplitude * math.Sin(2*math.Pi*seconds/period))
+
+	g.frame.Bones["Neck"] = pose(vmc.AxisAngle(vmc.Vec3{X: 0, Y: 1, Z: 0}, yaw/3))
+	g.frame.Bones["Head"] = pose(vmc.AxisAngle(vmc.Vec3{X: 0, Y: 1, Z: 0}, yaw*2/3))
+}
+
+// wave raises the right forearm and swings it from side to side, while the left arm hangs down.
+func (g *Generator) wave(seconds float64) {
+	const (
+		frequency = 1.5
+		amplitude = 25
+	)
+
+	swing := float32(amplitude * math.Sin(2*math.Pi*frequency*seconds))
+
+	g.frame.Bones["LeftUpperArm"] = pose(vmc.AxisAngle(vmc.Vec3{X: 0, Y: 0, Z: 1}, 70))
+	g.frame.Bones["RightUpperArm"] = pose(vmc.AxisAngle(vmc.Vec3{X: 0, Y: 0, Z: 1}, 20))
+	g.frame.Bones["RightLowerArm"] = pose(vmc.AxisAngle(vmc.Vec3{X: 0, Y: 0, Z: 1}, 70+swing))
+}
+
+// blink calculates the blink value, and schedules the next blink once the previous one started.
+func (g *Generator) blink(elapsed time.Duration) float32 {
+	for elapsed >= g.nextBlink {
+		g.lastBlink = g.nextBlink
+		g.nextBlink += g.blinkDelay()
+	}
+
+	since := elapsed - g.lastBlink
+
+	switch {
+	case since < 0 || since >= blinkClose+blinkOpen:
+		return 0
+	case since < blinkClose:
+		return float32(since) / float32(blinkClose)
+	default:
+		return 1 - float32(since-blinkClose)/float32(blinkOpen)
+	}
+}
+
+// blinkDelay picks a random delay between half and one and a half times the blink interval.
+func (g *Generator) blinkDelay() time.Duration {
+	return g.config.BlinkInterval/2 + time.Duration(g.random.Int63n(int64(g.config.BlinkInterval)))
+}
+
+// lipSync opens the mouth for the current phoneme, following a sine curve over its duration.
+func (g *Generator) lipSync(elapsed time.Duration) {
+	vowels := [...]vmc.VRM0Preset{vmc.VRM0A, vmc.VRM0I, vmc.VRM0U, vmc.VRM0E, vmc.VRM0O}
+	for _, vowel := range vowels {
+		g.frame.BlendShapes[vowel.String()] = 0
+	}
+
+	if g.config.Phonemes == "" {
+		return
+	}
+
+	index := elapsed / g.config.PhonemeDuration
+	progress := float64(elapsed%g.config.PhonemeDuration) / float64(g.config.PhonemeDuration)
+
+	if vowel, ok := phoneme(g.config.Phonemes[int(index)%len(g.config.Phonemes)]); ok {
+		g.frame.BlendShapes[vowel.String()] = float32(math.Sin(math.Pi * progress))
+	}
+}
+
+// phoneme finds the VRM preset for a vowel.
+func phoneme(c byte) (vmc.VRM0Preset, bool) {
+	switch c {
+	case 'a':
+		return vmc.VRM0A, true
+	case 'i':
+		return vmc.VRM0I, true
+	case 'u':
+		return vmc.VRM0U, true
+	case 'e':
+		return vmc.VRM0E, true
+	case 'o':
+		return vmc.VRM0O, true
+	default:
+		return vmc.VRM0Neutral, false
+	}
+}
+
+func identity() vmc.Vec4 {
+	return vmc.Vec4{X: 0, Y: 0, Z: 0, W: 1}
+}
+
+func pose(rotation vmc.Vec4) vmc.Pose {
+	return vmc.Pose{Position: vmc.Vec3{X: 0, Y: 0, Z: 0}, Quaternion: rotation}
+}
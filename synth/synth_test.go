@@ -0,0 +1,113 @@
+package synth_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/dnaka91/go-vmcparser/synth"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeterministic(t *testing.T) {
+	config := synth.Config{Phonemes: "aiueo", Seed: 5}
+	a, b := synth.New(config), synth.New(config)
+
+	for elapsed := time.Duration(0); elapsed < 10*time.Second; elapsed += a.Interval() {
+		require.Equal(t, a.Bundle(elapsed), b.Bundle(elapsed), elapsed)
+	}
+
+	assert.Equal(t, time.Second/60, a.Interval())
+}
+
+func TestNegativeConfig(t *testing.T) {
+	g := synth.New(synth.Config{Rate: -30, BlinkInterval: -time.Second, Phonemes: "aiueo", PhonemeDuration: -1})
+	assert.Equal(t, time.Second/60, g.Interval())
+
+	assert.NotPanics(t, func() {
+		for elapsed := time.Duration(0); elapsed < 10*time.Second; elapsed += g.Interval() {
+			g.Bundle(elapsed)
+		}
+	})
+}
+
+func TestRateLimits(t *testing.T) {
+	assert.Equal(t, time.Second/60, synth.New(synth.Config{Rate: math.Inf(1)}).Interval())
+	assert.Equal(t, time.Second/60, synth.New(synth.Config{Rate: math.NaN()}).Interval())
+	assert.Equal(t, time.Duration(1), synth.New(synth.Config{Rate: 2e9}).Interval())
+}
+
+func TestMotions(t *testing.T) {
+	g := synth.New(synth.Config{Motions: synth.MotionHeadTurn | synth.MotionLipSync, Phonemes: "a-o"})
+
+	frame := g.Frame(1500 * time.Millisecond)
+	assert.Equal(t, float32(1.5), frame.Time)
+	assert.Contains(t, frame.Bones, "Head")
+	assert.NotContains(t, frame.Bones, "RightLowerArm")
+	assert.NotContains(t, frame.BlendShapes, "Blink")
+
+	// The head turns right during the first half of the period.
+	forward := frame.Bones["Head"].Quaternion.Rotate(vmc.Vec3{X: 0, Y: 0, Z: 1})
+	assert.Greater(t, forward.X, float32(0))
+
+	// Each phoneme lasts 150ms and peaks in its middle.
+	frame = g.Frame(75 * time.Millisecond)
+	assert.InDelta(t, 1, frame.BlendShapes["A"], 1e-6)
+	assert.Zero(t, frame.BlendShapes["O"])
+
+	frame = g.Frame(225 * time.Millisecond)
+	assert.Zero(t, frame.BlendShapes["A"])
+	assert.Zero(t, frame.BlendShapes["O"])
+
+	frame = g.Frame(375 * time.Millisecond)
+	assert.InDelta(t, 1, frame.BlendShapes["O"], 1e-6)
+}
+
+func TestBlink(t *testing.T) {
+	g := synth.New(synth.Config{Motions: synth.MotionBlink, BlinkInterval: time.Second})
+
+	var blinks int
+
+	closed := false
+
+	for elapsed := time.Duration(0); elapsed < 10*time.Second; elapsed += 10 * time.Millisecond {
+		value := g.Frame(elapsed).BlendShapes["Blink"]
+		require.True(t, value >= 0 && value <= 1, value)
+
+		if value > 0.9 && !closed {
+			blinks++
+		}
+
+		closed = value > 0.9
+	}
+
+	// The intervals vary between half a second and one and a half seconds.
+	assert.GreaterOrEqual(t, blinks, 6)
+	assert.LessOrEqual(t, blinks, 20)
+}
+
+func TestBundle(t *testing.T) {
+	g := synth.New(synth.Config{})
+
+	packet, rest, err := osc.ReadPacket(g.Bundle(time.Second))
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+	require.NotNil(t, packet.Bundle)
+
+	var decoder vmc.Decoder
+
+	for i, content := range packet.Bundle.Contents {
+		msg, err := decoder.Decode(content.Message.Raw)
+		require.NoError(t, err, i)
+		require.NoError(t, vmc.Validate(msg), i)
+	}
+
+	first := packet.Bundle.Contents[0].Message
+	last := packet.Bundle.Contents[len(packet.Bundle.Contents)-1].Message
+
+	assert.Equal(t, vmc.AddressAvailable, string(first.Address))
+	assert.Equal(t, vmc.AddressBlendShapeProxyApply, string(last.Address))
+}
@@ -0,0 +1,371 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidAddress is returned when listening on an empty address, or sending without one.
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrAddressInUse is returned when listening on an address, that is already taken.
+	ErrAddressInUse = errors.New("address already in use")
+)
+
+// Default values, that are used for zero or negative values in the Config.
+const (
+	DefaultReorderDelay = 10 * time.Millisecond
+	DefaultBuffer       = 1024
+)
+
+// Config describes the conditions of an in-memory network. The zero value is a perfect network,
+// that delivers all packets immediately and in order.
+type Config struct {
+	// Latency is the delay of every packet.
+	Latency time.Duration
+	// Jitter is the maximum random delay, that is added to the latency of each packet. Packets
+	// may overtake each other, if it's greater than the time between them.
+	Jitter time.Duration
+	// Loss is the probability from 0 to 1, that a packet is dropped.
+	Loss float64
+	// Reorder is the probability from 0 to 1, that a packet is held back by the ReorderDelay, so
+	// that later packets overtake it.
+	Reorder float64
+	// ReorderDelay is the additional delay of reordered packets. If zero or negative,
+	// DefaultReorderDelay is used.
+	ReorderDelay time.Duration
+	// Duplicate is the probability from 0 to 1, that a packet is delivered twice. Each copy gets
+	// its own delay.
+	Duplicate float64
+	// Buffer is the amount of packets, that each connection can hold before it drops further
+	// packets, like the receive buffer of a socket. If zero or negative, DefaultBuffer is used.
+	Buffer int
+	// Seed initializes the randomness of the network conditions. Networks with the same
+	// configuration and the same sequence of operations behave exactly the same.
+	Seed int64
+}
+
+// Addr is the address of an in-memory connection, which can be any non-empty name.
+type Addr string
+
+var _ net.Addr = Addr("")
+
+// Network returns the name of the network, which is always "memory".
+func (a Addr) Network() string {
+	return "memory"
+}
+
+func (a Addr) String() string {
+	return string(a)
+}
+
+// Network connects in-memory connections with each other.
+//
+// The network runs on a virtual clock, that only moves forward through Advance and Flush. Packets
+// without delay are delivered immediately, while all others wait until the clock reaches their
+// delivery time. That way, tests don't depend on timing, and stay fast even with high latencies.
+//
+// A network is safe for concurrent use.
+type Network struct {
+	config  Config
+	mu      sync.Mutex
+	random  *rand.Rand
+	now     time.Duration
+	seq     uint64
+	conns   map[Addr]*Conn
+	pending []packet
+}
+
+// packet is a single packet in flight.
+type packet struct {
+	at   time.Duration
+	seq  uint64
+	from Addr
+	to   Addr
+	data []byte
+}
+
+// NewNetwork creates a new in-memory network with the given conditions.
+func NewNetwork(config Config) *Network {
+	if config.ReorderDelay <= 0 {
+		config.ReorderDelay = DefaultReorderDelay
+	}
+
+	if config.Buffer <= 0 {
+		config.Buffer = DefaultBuffer
+	}
+
+	return &Network{
+		config: config,
+		mu:     sync.Mutex{},
+		// The randomness must be reproducible, to make tests deterministic.
+		random:  rand.New(rand.NewSource(config.Seed)),
+		now:     0,
+		seq:     0,
+		conns:   make(map[Addr]*Conn),
+		pending: nil,
+	}
+}
+
+// Listen creates a new connection at the address.
+func (n *Network) Listen(address string) (*Conn, error) {
+	if address == "" {
+		return nil, ErrInvalidAddress
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	addr := Addr(address)
+	if _, ok := n.conns[addr]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrAddressInUse, address)
+	}
+
+	conn := &Conn{
+		network:   n,
+		addr:      addr,
+		inbox:     make(chan packet, n.config.Buffer),
+		closed:    make(chan struct{}),
+		closeOnce: sync.Once{},
+		mu:        sync.Mutex{},
+		deadline:  time.Time{},
+		changed:   make(chan struct{}),
+	}
+	n.conns[addr] = conn
+
+	return conn, nil
+}
+
+// Advance moves the virtual clock forward, and delivers all packets that are due.
+func (n *Network) Advance(d time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.now += d
+	n.deliver()
+}
+
+// Flush moves the virtual clock forward until all packets in flight are delivered.
+func (n *Network) Flush() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, p := range n.pending {
+		if p.at > n.now {
+			n.now = p.at
+		}
+	}
+
+	n.deliver()
+}
+
+// Pending returns the amount of packets, that are still in flight.
+func (n *Network) Pending() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return len(n.pending)
+}
+
+// send applies the network conditions to a packet, and queues it for delivery.
+func (n *Network) send(from Addr, data []byte, to net.Addr) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.chance(n.config.Loss) {
+		return
+	}
+
+	copies := 1
+	if n.chance(n.config.Duplicate) {
+		copies = 2
+	}
+
+	data = append([]byte(nil), data...)
+
+	for i := 0; i < copies; i++ {
+		delay := n.config.Latency
+		if n.config.Jitter > 0 {
+			delay += time.Duration(n.random.Int63n(int64(n.config.Jitter) + 1))
+		}
+
+		if n.chance(n.config.Reorder) {
+			delay += n.config.ReorderDelay
+		}
+
+		n.seq++
+		n.pending = append(n.pending, packet{
+			at:   n.now + delay,
+			seq:  n.seq,
+			from: from,
+			to:   Addr(to.String()),
+			data: data,
+		})
+	}
+
+	n.deliver()
+}
+
+// chance randomly returns true with the given probability. No randomness is used up for zero
+// probabilities, so that disabled conditions don't affect the enabled ones.
+func (n *Network) chance(probability float64) bool {
+	return probability > 0 && n.random.Float64() < probability
+}
+
+// deliver hands all due packets to their receivers, in the order of their delivery time. Packets
+// to unknown addresses, or to receivers with full buffers, are dropped.
+func (n *Network) deliver() {
+	sort.Slice(n.pending, func(i, j int) bool {
+		if n.pending[i].at != n.pending[j].at {
+			return n.pending[i].at < n.pending[j].at
+		}
+
+		return n.pending[i].seq < n.pending[j].seq
+	})
+
+	due := 0
+	for due < len(n.pending) && n.pending[due].at <= n.now {
+		p := n.pending[due]
+		due++
+
+		conn, ok := n.conns[p.to]
+		if !ok {
+			continue
+		}
+
+		select {
+		case conn.inbox <- p:
+		default:
+		}
+	}
+
+	n.pending = append(n.pending[:0], n.pending[due:]...)
+}
+
+func (n *Network) remove(conn *Conn) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conns[conn.addr] == conn {
+		delete(n.conns, conn.addr)
+	}
+}
+
+// Conn is an in-memory connection within a Network. It's safe for concurrent use.
+type Conn struct {
+	network   *Network
+	addr      Addr
+	inbox     chan packet
+	closed    chan struct{}
+	closeOnce sync.Once
+	mu        sync.Mutex
+	deadline  time.Time
+	// changed is closed and replaced whenever the read deadline changes, to wake up pending reads.
+	changed chan struct{}
+}
+
+// ReadFrom waits for the next packet. Like with sockets, changes of the read deadline also apply
+// to reads, that are already waiting.
+func (c *Conn) ReadFrom(p []byte) (int, net.Addr, error) {
+	for {
+		select {
+		case <-c.closed:
+			return 0, nil, net.ErrClosed
+		default:
+		}
+
+		c.mu.Lock()
+		deadline, changed := c.deadline, c.changed
+		c.mu.Unlock()
+
+		received, ok, err := c.wait(deadline, changed)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if ok {
+			return copy(p, received.data), received.from, nil
+		}
+	}
+}
+
+// wait waits for the next packet until the deadline. It returns false, if the deadline changed in
+// the meantime, and the read must be retried.
+func (c *Conn) wait(deadline time.Time, changed <-chan struct{}) (packet, bool, error) {
+	var timeout <-chan time.Time
+
+	if !deadline.IsZero() {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return packet{}, false, os.ErrDeadlineExceeded
+		}
+
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case received := <-c.inbox:
+		return received, true, nil
+	case <-c.closed:
+		return packet{}, false, net.ErrClosed
+	case <-timeout:
+		return packet{}, false, os.ErrDeadlineExceeded
+	case <-changed:
+		return packet{}, false, nil
+	}
+}
+
+// WriteTo sends the packet through the network. Like with UDP, packets to unknown addresses are
+// silently dropped.
+func (c *Conn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, net.ErrClosed
+	default:
+	}
+
+	if addr == nil {
+		return 0, fmt.Errorf("%w: missing destination", ErrInvalidAddress)
+	}
+
+	c.network.send(c.addr, p, addr)
+
+	return len(p), nil
+}
+
+// LocalAddr returns the address of the connection.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.addr
+}
+
+// SetReadDeadline sets the deadline for future and pending reads.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deadline = t
+
+	close(c.changed)
+	c.changed = make(chan struct{})
+
+	return nil
+}
+
+// Close closes the connection, and frees up its address.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.network.remove(c)
+	})
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package transport_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dnaka91/go-vmcparser/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listen(t *testing.T, network *transport.Network, address string) *transport.Conn {
+	t.Helper()
+
+	conn, err := network.Listen(address)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+func send(t *testing.T, conn transport.PacketConn, to net.Addr, count int) {
+	t.Helper()
+
+	for i := 0; i < count; i++ {
+		_, err := conn.WriteTo([]byte{byte(i)}, to)
+		require.NoError(t, err)
+	}
+}
+
+// receive reads all packets, that were delivered so far.
+func receive(t *testing.T, conn transport.PacketConn) []byte {
+	t.Helper()
+
+	var received []byte
+
+	buf := make([]byte, 16)
+
+	for {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Millisecond)))
+
+		n, _, err := conn.ReadFrom(buf)
+		if os.IsTimeout(err) {
+			return received
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+
+		received = append(received, buf[0])
+	}
+}
+
+func TestLoopback(t *testing.T) {
+	network := transport.NewNetwork(transport.Config{})
+	a, b := listen(t, network, "a"), listen(t, network, "b")
+
+	_, err := a.WriteTo([]byte("hello"), b.LocalAddr())
+	require.NoError(t, err)
+
+	buf := make([]byte, 3)
+	n, from, err := b.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hel", string(buf[:n]), "packets are truncated like with UDP")
+	assert.Equal(t, transport.Addr("a"), from)
+	assert.Equal(t, "memory", from.Network())
+
+	_, err = network.Listen("a")
+	assert.ErrorIs(t, err, transport.ErrAddressInUse)
+
+	_, err = network.Listen("")
+	assert.ErrorIs(t, err, transport.ErrInvalidAddress)
+
+	require.NoError(t, a.Close())
+
+	_, _, err = a.ReadFrom(buf)
+	assert.ErrorIs(t, err, net.ErrClosed)
+
+	_, err = a.WriteTo(buf, b.LocalAddr())
+	assert.ErrorIs(t, err, net.ErrClosed)
+
+	// The address is free again after closing.
+	listen(t, network, "a")
+}
+
+func TestLatency(t *testing.T) {
+	network := transport.NewNetwork(transport.Config{Latency: 50 * time.Millisecond})
+	a, b := listen(t, network, "a"), listen(t, network, "b")
+
+	send(t, a, b.LocalAddr(), 3)
+	assert.Equal(t, 3, network.Pending())
+
+	network.Advance(49 * time.Millisecond)
+	assert.Empty(t, receive(t, b))
+
+	network.Advance(time.Millisecond)
+	assert.Equal(t, []byte{0, 1, 2}, receive(t, b))
+	assert.Zero(t, network.Pending())
+}
+
+func TestConditions(t *testing.T) {
+	tests := []struct {
+		name   string
+		config transport.Config
+		check  func(t *testing.T, received []byte)
+	}{
+		{
+			name:   "loss",
+			config: transport.Config{Loss: 0.5},
+			check: func(t *testing.T, received []byte) {
+				t.Helper()
+				assert.Greater(t, len(received), 30)
+				assert.Less(t, len(received), 70)
+			},
+		},
+		{
+			name:   "duplication",
+			config: transport.Config{Duplicate: 0.5},
+			check: func(t *testing.T, received []byte) {
+				t.Helper()
+				assert.Greater(t, len(received), 130)
+				assert.Less(t, len(received), 170)
+			},
+		},
+		{
+			name:   "reordering",
+			config: transport.Config{Reorder: 0.2},
+			check: func(t *testing.T, received []byte) {
+				t.Helper()
+				assert.Len(t, received, 100)
+				assert.NotEqual(t, sequence(100), received)
+			},
+		},
+		{
+			name:   "jitter",
+			config: transport.Config{Latency: time.Millisecond, Jitter: time.Second},
+			check: func(t *testing.T, received []byte) {
+				t.Helper()
+				assert.Len(t, received, 100)
+				assert.NotEqual(t, sequence(100), received)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			run := func(seed int64) []byte {
+				config := tt.config
+				config.Seed = seed
+
+				network := transport.NewNetwork(config)
+				a, b := listen(t, network, fmt.Sprint("a", seed)), listen(t, network, fmt.Sprint("b", seed))
+
+				send(t, a, b.LocalAddr(), 100)
+				network.Flush()
+
+				return receive(t, b)
+			}
+
+			received := run(1)
+			tt.check(t, received)
+			assert.Equal(t, received, run(1), "same seed must give the same result")
+		})
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	network := transport.NewNetwork(transport.Config{Buffer: 2})
+	a, b := listen(t, network, "a"), listen(t, network, "b")
+
+	send(t, a, b.LocalAddr(), 5)
+	send(t, a, transport.Addr("unknown"), 1)
+
+	assert.Equal(t, []byte{0, 1}, receive(t, b))
+}
+
+func TestDeadlineChange(t *testing.T) {
+	network := transport.NewNetwork(transport.Config{})
+	a := listen(t, network, "a")
+
+	done := make(chan error)
+	go func() {
+		_, _, err := a.ReadFrom(make([]byte, 16))
+		done <- err
+	}()
+
+	// Moving the deadline further out must keep the read waiting.
+	require.NoError(t, a.SetReadDeadline(time.Now().Add(time.Hour)))
+
+	select {
+	case err := <-done:
+		t.Fatalf("read returned early: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	require.NoError(t, a.SetReadDeadline(time.Now().Add(-time.Second)))
+
+	select {
+	case err := <-done:
+		assert.True(t, os.IsTimeout(err))
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending read was not unblocked by the deadline")
+	}
+}
+
+func TestMissingAddress(t *testing.T) {
+	network := transport.NewNetwork(transport.Config{})
+	a := listen(t, network, "a")
+
+	_, err := a.WriteTo([]byte{0}, nil)
+	assert.ErrorIs(t, err, transport.ErrInvalidAddress)
+	assert.Zero(t, network.Pending())
+}
+
+func TestNegativeConfig(t *testing.T) {
+	network := transport.NewNetwork(transport.Config{Reorder: 1, ReorderDelay: -1, Buffer: -1})
+	a, b := listen(t, network, "a"), listen(t, network, "b")
+
+	send(t, a, b.LocalAddr(), 2)
+	assert.Equal(t, 2, network.Pending(), "reordered packets must still be delayed")
+
+	network.Flush()
+	assert.Equal(t, []byte{0, 1}, receive(t, b))
+}
+
+func TestUDP(t *testing.T) {
+	conn, err := transport.ListenUDP("127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	send(t, conn, conn.LocalAddr(), 2)
+	assert.Equal(t, []byte{0, 1}, receive(t, conn))
+}
+
+func sequence(n int) []byte {
+	s := make([]byte, n)
+	for i := range s {
+		s[i] = byte(i)
+	}
+
+	return s
+}
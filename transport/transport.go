@@ -0,0 +1,45 @@
+// Package transport abstracts the packet based connections, that VMC messages are exchanged over.
+//
+// Receivers, senders and proxies are written against the PacketConn interface, which is satisfied
+// by UDP sockets of the net package. For tests, a Network connects any amount of in-memory
+// connections instead, and can simulate latency, packet loss, reordering and duplication in a
+// deterministic way.
+package transport
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// PacketConn is a packet oriented connection. It's the subset of net.PacketConn, that is needed to
+// exchange messages, so any net.PacketConn can be used as well.
+type PacketConn interface {
+	// ReadFrom reads a single packet into p, and returns the amount of bytes and the sender. If p
+	// is too small, the remainder of the packet is discarded.
+	ReadFrom(p []byte) (n int, addr net.Addr, err error)
+	// WriteTo sends p as single packet to the address.
+	WriteTo(p []byte, addr net.Addr) (n int, err error)
+	// LocalAddr returns the address of the connection itself.
+	LocalAddr() net.Addr
+	// SetReadDeadline sets the time, after which reads fail with os.ErrDeadlineExceeded. The zero
+	// value disables the deadline.
+	SetReadDeadline(t time.Time) error
+	// Close closes the connection, making any further reads and writes fail with net.ErrClosed.
+	Close() error
+}
+
+var (
+	_ PacketConn = (*net.UDPConn)(nil)
+	_ PacketConn = (*Conn)(nil)
+)
+
+// ListenUDP opens a UDP socket at the address, like ":39539" for the VMC default port.
+func ListenUDP(address string) (PacketConn, error) {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed listening on %s: %w", address, err)
+	}
+
+	return conn, nil
+}
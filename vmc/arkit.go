@@ -0,0 +1,350 @@
+package vmc
+
+import "fmt"
+
+// ARKitBlendShape is one of the 52 blend shapes of Apple's ARKit face tracking. Face trackers with
+// "perfect sync" support send them with their ARKit names as BlendShapeProxyValue messages.
+type ARKitBlendShape uint8
+
+// Possible values for the ARKit blend shapes, in the order of Apple's documentation.
+const (
+	ARKitEyeBlinkLeft ARKitBlendShape = iota
+	ARKitEyeLookDownLeft
+	ARKitEyeLookInLeft
+	ARKitEyeLookOutLeft
+	ARKitEyeLookUpLeft
+	ARKitEyeSquintLeft
+	ARKitEyeWideLeft
+	ARKitEyeBlinkRight
+	ARKitEyeLookDownRight
+	ARKitEyeLookInRight
+	ARKitEyeLookOutRight
+	ARKitEyeLookUpRight
+	ARKitEyeSquintRight
+	ARKitEyeWideRight
+	ARKitJawForward
+	ARKitJawLeft
+	ARKitJawRight
+	ARKitJawOpen
+	ARKitMouthClose
+	ARKitMouthFunnel
+	ARKitMouthPucker
+	ARKitMouthLeft
+	ARKitMouthRight
+	ARKitMouthSmileLeft
+	ARKitMouthSmileRight
+	ARKitMouthFrownLeft
+	ARKitMouthFrownRight
+	ARKitMouthDimpleLeft
+	ARKitMouthDimpleRight
+	ARKitMouthStretchLeft
+	ARKitMouthStretchRight
+	ARKitMouthRollLower
+	ARKitMouthRollUpper
+	ARKitMouthShrugLower
+	ARKitMouthShrugUpper
+	ARKitMouthPressLeft
+	ARKitMouthPressRight
+	ARKitMouthLowerDownLeft
+	ARKitMouthLowerDownRight
+	ARKitMouthUpperUpLeft
+	ARKitMouthUpperUpRight
+	ARKitBrowDownLeft
+	ARKitBrowDownRight
+	ARKitBrowInnerUp
+	ARKitBrowOuterUpLeft
+	ARKitBrowOuterUpRight
+	ARKitCheekPuff
+	ARKitCheekSquintLeft
+	ARKitCheekSquintRight
+	ARKitNoseSneerLeft
+	ARKitNoseSneerRight
+	ARKitTongueOut
+
+	// ARKitBlendShapeCount is the amount of ARKit blend shapes.
+	ARKitBlendShapeCount = int(ARKitTongueOut) + 1
+)
+
+// String returns the ARKit name of the blend shape, like eyeBlinkLeft.
+func (s ARKitBlendShape) String() string {
+	switch s {
+	case ARKitEyeBlinkLeft:
+		return "eyeBlinkLeft"
+	case ARKitEyeLookDownLeft:
+		return "eyeLookDownLeft"
+	case ARKitEyeLookInLeft:
+		return "eyeLookInLeft"
+	case ARKitEyeLookOutLeft:
+		return "eyeLookOutLeft"
+	case ARKitEyeLookUpLeft:
+		return "eyeLookUpLeft"
+	case ARKitEyeSquintLeft:
+		return "eyeSquintLeft"
+	case ARKitEyeWideLeft:
+		return "eyeWideLeft"
+	case ARKitEyeBlinkRight:
+		return "eyeBlinkRight"
+	case ARKitEyeLookDownRight:
+		return "eyeLookDownRight"
+	case ARKitEyeLookInRight:
+		return "eyeLookInRight"
+	case ARKitEyeLookOutRight:
+		return "eyeLookOutRight"
+	case ARKitEyeLookUpRight:
+		return "eyeLookUpRight"
+	case ARKitEyeSquintRight:
+		return "eyeSquintRight"
+	case ARKitEyeWideRight:
+		return "eyeWideRight"
+	case ARKitJawForward:
+		return "jawForward"
+	case ARKitJawLeft:
+		return "jawLeft"
+	case ARKitJawRight:
+		return "jawRight"
+	case ARKitJawOpen:
+		return "jawOpen"
+	case ARKitMouthClose:
+		return "mouthClose"
+	case ARKitMouthFunnel:
+		return "mouthFunnel"
+	case ARKitMouthPucker:
+		return "mouthPucker"
+	case ARKitMouthLeft:
+		return "mouthLeft"
+	case ARKitMouthRight:
+		return "mouthRight"
+	case ARKitMouthSmileLeft:
+		return "mouthSmileLeft"
+	case ARKitMouthSmileRight:
+		return "mouthSmileRight"
+	case ARKitMouthFrownLeft:
+		return "mouthFrownLeft"
+	case ARKitMouthFrownRight:
+		return "mouthFrownRight"
+	case ARKitMouthDimpleLeft:
+		return "mouthDimpleLeft"
+	case ARKitMouthDimpleRight:
+		return "mouthDimpleRight"
+	case ARKitMouthStretchLeft:
+		return "mouthStretchLeft"
+	case ARKitMouthStretchRight:
+		return "mouthStretchRight"
+	case ARKitMouthRollLower:
+		return "mouthRollLower"
+	case ARKitMouthRollUpper:
+		return "mouthRollUpper"
+	case ARKitMouthShrugLower:
+		return "mouthShrugLower"
+	case ARKitMouthShrugUpper:
+		return "mouthShrugUpper"
+	case ARKitMouthPressLeft:
+		return "mouthPressLeft"
+	case ARKitMouthPressRight:
+		return "mouthPressRight"
+	case ARKitMouthLowerDownLeft:
+		return "mouthLowerDownLeft"
+	case ARKitMouthLowerDownRight:
+		return "mouthLowerDownRight"
+	case ARKitMouthUpperUpLeft:
+		return "mouthUpperUpLeft"
+	case ARKitMouthUpperUpRight:
+		return "mouthUpperUpRight"
+	case ARKitBrowDownLeft:
+		return "browDownLeft"
+	case ARKitBrowDownRight:
+		return "browDownRight"
+	case ARKitBrowInnerUp:
+		return "browInnerUp"
+	case ARKitBrowOuterUpLeft:
+		return "browOuterUpLeft"
+	case ARKitBrowOuterUpRight:
+		return "browOuterUpRight"
+	case ARKitCheekPuff:
+		return "cheekPuff"
+	case ARKitCheekSquintLeft:
+		return "cheekSquintLeft"
+	case ARKitCheekSquintRight:
+		return "cheekSquintRight"
+	case ARKitNoseSneerLeft:
+		return "noseSneerLeft"
+	case ARKitNoseSneerRight:
+		return "noseSneerRight"
+	case ARKitTongueOut:
+		return "tongueOut"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(s))
+	}
+}
+
+// ParseARKitBlendShape finds the ARKit blend shape with the given name. Besides the ARKit names,
+// like eyeBlinkLeft, the capitalized variants like EyeBlinkLeft are accepted as well, as used by
+// several avatars.
+func ParseARKitBlendShape(name []byte) (ARKitBlendShape, bool) {
+	// Long enough for the longest name, mouthLowerDownRight.
+	var buf [19]byte
+
+	if len(name) == 0 || len(name) > len(buf) {
+		return 0, false
+	}
+
+	copy(buf[:], name)
+
+	if buf[0] >= 'A' && buf[0] <= 'Z' {
+		buf[0] += 'a' - 'A'
+	}
+
+	switch string(buf[:len(name)]) {
+	case "eyeBlinkLeft":
+		return ARKitEyeBlinkLeft, true
+	case "eyeLookDownLeft":
+		return ARKitEyeLookDownLeft, true
+	case "eyeLookInLeft":
+		return ARKitEyeLookInLeft, true
+	case "eyeLookOutLeft":
+		return ARKitEyeLookOutLeft, true
+	case "eyeLookUpLeft":
+		return ARKitEyeLookUpLeft, true
+	case "eyeSquintLeft":
+		return ARKitEyeSquintLeft, true
+	case "eyeWideLeft":
+		return ARKitEyeWideLeft, true
+	case "eyeBlinkRight":
+		return ARKitEyeBlinkRight, true
+	case "eyeLookDownRight":
+		return ARKitEyeLookDownRight, true
+	case "eyeLookInRight":
+		return ARKitEyeLookInRight, true
+	case "eyeLookOutRight":
+		return ARKitEyeLookOutRight, true
+	case "eyeLookUpRight":
+		return ARKitEyeLookUpRight, true
+	case "eyeSquintRight":
+		return ARKitEyeSquintRight, true
+	case "eyeWideRight":
+		return ARKitEyeWideRight, true
+	case "jawForward":
+		return ARKitJawForward, true
+	case "jawLeft":
+		return ARKitJawLeft, true
+	case "jawRight":
+		return ARKitJawRight, true
+	case "jawOpen":
+		return ARKitJawOpen, true
+	case "mouthClose":
+		return ARKitMouthClose, true
+	case "mouthFunnel":
+		return ARKitMouthFunnel, true
+	case "mouthPucker":
+		return ARKitMouthPucker, true
+	case "mouthLeft":
+		return ARKitMouthLeft, true
+	case "mouthRight":
+		return ARKitMouthRight, true
+	case "mouthSmileLeft":
+		return ARKitMouthSmileLeft, true
+	case "mouthSmileRight":
+		return ARKitMouthSmileRight, true
+	case "mouthFrownLeft":
+		return ARKitMouthFrownLeft, true
+	case "mouthFrownRight":
+		return ARKitMouthFrownRight, true
+	case "mouthDimpleLeft":
+		return ARKitMouthDimpleLeft, true
+	case "mouthDimpleRight":
+		return ARKitMouthDimpleRight, true
+	case "mouthStretchLeft":
+		return ARKitMouthStretchLeft, true
+	case "mouthStretchRight":
+		return ARKitMouthStretchRight, true
+	case "mouthRollLower":
+		return ARKitMouthRollLower, true
+	case "mouthRollUpper":
+		return ARKitMouthRollUpper, true
+	case "mouthShrugLower":
+		return ARKitMouthShrugLower, true
+	case "mouthShrugUpper":
+		return ARKitMouthShrugUpper, true
+	case "mouthPressLeft":
+		return ARKitMouthPressLeft, true
+	case "mouthPressRight":
+		return ARKitMouthPressRight, true
+	case "mouthLowerDownLeft":
+		return ARKitMouthLowerDownLeft, true
+	case "mouthLowerDownRight":
+		return ARKitMouthLowerDownRight, true
+	case "mouthUpperUpLeft":
+		return ARKitMouthUpperUpLeft, true
+	case "mouthUpperUpRight":
+		return ARKitMouthUpperUpRight, true
+	case "browDownLeft":
+		return ARKitBrowDownLeft, true
+	case "browDownRight":
+		return ARKitBrowDownRight, true
+	case "browInnerUp":
+		return ARKitBrowInnerUp, true
+	case "browOuterUpLeft":
+		return ARKitBrowOuterUpLeft, true
+	case "browOuterUpRight":
+		return ARKitBrowOuterUpRight, true
+	case "cheekPuff":
+		return ARKitCheekPuff, true
+	case "cheekSquintLeft":
+		return ARKitCheekSquintLeft, true
+	case "cheekSquintRight":
+		return ARKitCheekSquintRight, true
+	case "noseSneerLeft":
+		return ARKitNoseSneerLeft, true
+	case "noseSneerRight":
+		return ARKitNoseSneerRight, true
+	case "tongueOut":
+		return ARKitTongueOut, true
+	default:
+		return 0, false
+	}
+}
+
+// ARKit returns the ARKit blend shape, if the name of the message is one of them.
+func (b *BlendShapeProxyValue) ARKit() (ARKitBlendShape, bool) {
+	return ParseARKitBlendShape(b.Name)
+}
+
+// ARKitWeight is the share of a single ARKit blend shape in an expression.
+type ARKitWeight struct {
+	Shape  ARKitBlendShape
+	Weight float32
+}
+
+// ARKitValues collects the latest value of each ARKit blend shape. It's used to derive VRM
+// expressions for avatars, that don't have the ARKit blend shapes themselves.
+type ARKitValues [ARKitBlendShapeCount]float32
+
+// Set stores the value of the message, if it's an ARKit blend shape. It reports whether the
+// message was recognized.
+func (v *ARKitValues) Set(msg *BlendShapeProxyValue) bool {
+	shape, ok := msg.ARKit()
+	if !ok {
+		return false
+	}
+
+	v[shape] = msg.Value
+
+	return true
+}
+
+// Expression calculates the weighted sum of the ARKit blend shapes, clamped to the range 0 to 1.
+func (v *ARKitValues) Expression(weights []ARKitWeight) float32 {
+	var value float32
+	for _, weight := range weights {
+		value += weight.Weight * v[weight.Shape]
+	}
+
+	switch {
+	case value < 0:
+		return 0
+	case value > 1:
+		return 1
+	default:
+		return value
+	}
+}
@@ -0,0 +1,76 @@
+package vmc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestARKitBlendShape(t *testing.T) {
+	assert.Equal(t, 52, vmc.ARKitBlendShapeCount)
+
+	for i := 0; i < vmc.ARKitBlendShapeCount; i++ {
+		shape := vmc.ARKitBlendShape(i)
+
+		parsed, ok := vmc.ParseARKitBlendShape([]byte(shape.String()))
+		assert.True(t, ok, shape)
+		assert.Equal(t, shape, parsed)
+	}
+
+	assert.Equal(t, "Unknown(52)", vmc.ARKitBlendShape(52).String())
+
+	tests := []struct {
+		name  string
+		shape vmc.ARKitBlendShape
+		ok    bool
+	}{
+		{"eyeBlinkLeft", vmc.ARKitEyeBlinkLeft, true},
+		{"EyeBlinkLeft", vmc.ARKitEyeBlinkLeft, true},
+		{"mouthLowerDownRight", vmc.ARKitMouthLowerDownRight, true},
+		{"tongueOut", vmc.ARKitTongueOut, true},
+		{"eyeblinkleft", 0, false},
+		{"Blink_L", 0, false},
+		{"mouthLowerDownRightX", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		msg := vmc.BlendShapeProxyValue{Name: []byte(tt.name), Value: 1}
+
+		shape, ok := msg.ARKit()
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.shape, shape, tt.name)
+	}
+}
+
+func TestARKitValues(t *testing.T) {
+	var values vmc.ARKitValues
+
+	assert.True(t, values.Set(&vmc.BlendShapeProxyValue{Name: []byte("eyeBlinkLeft"), Value: 1}))
+	assert.True(t, values.Set(&vmc.BlendShapeProxyValue{Name: []byte("EyeBlinkRight"), Value: 0.5}))
+	assert.False(t, values.Set(&vmc.BlendShapeProxyValue{Name: []byte("Blink"), Value: 1}))
+
+	assert.Equal(t, float32(1), values.Expression(vmc.VRM0BlinkLeft.ARKit()))
+	assert.Equal(t, float32(0.5), values.Expression(vmc.VRM1BlinkRight.ARKit()))
+	assert.Equal(t, float32(0.75), values.Expression(vmc.VRM0Blink.ARKit()))
+	assert.Equal(t, float32(0), values.Expression(vmc.VRM0Neutral.ARKit()))
+	assert.Equal(t, float32(1), values.Expression([]vmc.ARKitWeight{{Shape: vmc.ARKitEyeBlinkLeft, Weight: 2}}))
+}
+
+func TestVRMExpressions(t *testing.T) {
+	assert.Equal(t, "Blink_L", vmc.VRM0BlinkLeft.String())
+	assert.Equal(t, "blinkLeft", vmc.VRM0BlinkLeft.VRM1().String())
+	assert.Equal(t, vmc.VRM1Happy, vmc.VRM0Joy.VRM1())
+	assert.Equal(t, "Unknown(99)", vmc.VRM0Preset(99).String())
+	assert.Equal(t, "Unknown(99)", vmc.VRM1Expression(99).String())
+
+	for expression := vmc.VRM1Aa; expression <= vmc.VRM1Surprised; expression++ {
+		var sum float32
+		for _, weight := range expression.ARKit() {
+			sum += weight.Weight
+		}
+
+		assert.InDelta(t, 1, sum, 1e-6, expression)
+	}
+}
@@ -0,0 +1,103 @@
+package vmc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+// benchMessages contains a sample for each of the supported VMC messages. For messages with
+// several variants, the latest protocol version is used.
+func benchMessages() []struct {
+	name  string
+	input []byte
+} {
+	return []struct {
+		name  string
+		input []byte
+	}{
+		{"Available", []byte("/VMC/Ext/OK\x00,iiii\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x03\x00\x00\x00\x01\x00\x00\x00\x01")},
+		{"RelativeTime", []byte("/VMC/Ext/T\x00\x00,f\x00\x00\x40\xa0\x00\x00")},
+		{"RootTransform", []byte("/VMC/Ext/Root/Pos\x00\x00\x00,sfffffffffffff\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a\x40\x46\x66\x66\x40\x4c\xcc\xcd\x40\x53\x33\x33\x40\x83\x33\x33\x40\x86\x66\x66\x40\x89\x99\x9a")},
+		{"BoneTransform", []byte("/VMC/Ext/Bone/Pos\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a")},
+		{"BlendShapeProxyValue", []byte("/VMC/Ext/Blend/Val\x00\x00,sf\x00tst\x00\x40\xa0\x00\x00")},
+		{"BlendShapeProxyApply", []byte("/VMC/Ext/Blend/Apply\x00\x00\x00\x00,\x00\x00\x00")},
+		{"CameraTransform", []byte("/VMC/Ext/Cam\x00\x00\x00\x00,sffffffff\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a\x40\xa0\x00\x00")},
+		{"ControllerInput", []byte("/VMC/Ext/Con\x00\x00\x00\x00,isiiifff\x00\x00\x00\x00\x00\x00\x01tst\x00\x00\x00\x00\x01\x00\x00\x00\x01\x00\x00\x00\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66")},
+		{"KeyboardInput", []byte("/VMC/Ext/Key\x00\x00\x00\x00,isi\x00\x00\x00\x00\x00\x00\x00\x01tst\x00\x00\x00\x00\x05")},
+		{"MidiNoteInput", []byte("/VMC/Ext/Midi/Note\x00\x00,iiif\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x01\x00\x00\x00\x02\x3f\x8c\xcc\xcd")},
+		{"MidiCCValueInput", []byte("/VMC/Ext/Midi/CC/Val\x00\x00\x00\x00,if\x00\x00\x00\x00\x01\x3f\x8c\xcc\xcd")},
+		{"MidiCCButtonInput", []byte("/VMC/Ext/Midi/CC/Bit\x00\x00\x00\x00,ii\x00\x00\x00\x00\x01\x00\x00\x00\x01")},
+		{"DeviceTransform", []byte("/VMC/Ext/Hmd/Pos\x00\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a")},
+		{"ReceiveEnable", []byte("/VMC/Ext/Rcv\x00\x00\x00\x00,iis\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x1f\x90127.0.0.1\x00\x00\x00")},
+		{"DirectionalLight", []byte("/VMC/Ext/Light\x00\x00,sfffffffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a\x40\x46\x66\x66\x40\x4c\xcc\xcd\x40\x53\x33\x33\x40\x59\x99\x9a")},
+		{"LocalVrm", []byte("/VMC/Ext/VRM\x00\x00\x00\x00,sss\x00\x00\x00\x00t01\x00t02\x00t03\x00")},
+		{"RemoteVrm", []byte("/VMC/Ext/Remote\x00,ss\x00tst\x00{}\x00\x00")},
+		{"OptionString", []byte("/VMC/Ext/Opt\x00\x00\x00\x00,s\x00\x00tst\x00")},
+		{"BackgroundColor", []byte("/VMC/Ext/Setting/Color\x00\x00,ffff\x00\x00\x00\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a")},
+		{"WindowAttribute", []byte("/VMC/Ext/Setting/Win\x00\x00\x00\x00,iiii\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x01\x00\x00\x00\x01\x00\x00\x00\x01")},
+		{"LoadedSettingPath", []byte("/VMC/Ext/Config\x00,s\x00\x00tst\x00")},
+		{"EyeTarget", []byte("/VMC/Ext/Set/Eye\x00\x00\x00\x00,ifff\x00\x00\x00\x00\x00\x00\x01\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66")},
+	}
+}
+
+func BenchmarkParseMessage(b *testing.B) {
+	for _, bench := range benchMessages() {
+		input := bench.input
+
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := vmc.ParseMessage(input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	for _, bench := range benchMessages() {
+		input := bench.input
+
+		b.Run(bench.name, func(b *testing.B) {
+			var decoder vmc.Decoder
+
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := decoder.Decode(input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkParseMessageFiltered(b *testing.B) {
+	input := []byte("/VMC/Ext/Bone/Pos\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := vmc.ParseMessage(input, vmc.AddressRootTransform); err == nil {
+			b.Fatal("message must be filtered")
+		}
+	}
+}
+
+// TestParseMessageAllocs guards the allocation goal, as each message must not allocate more than
+// the resulting message value.
+func TestParseMessageAllocs(t *testing.T) {
+	for _, bench := range benchMessages() {
+		input := bench.input
+
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = vmc.ParseMessage(input)
+		})
+		if allocs > 1 {
+			t.Errorf("%s: got %v allocations, expected at most 1", bench.name, allocs)
+		}
+	}
+}
@@ -1,17 +1,36 @@
 package vmc
 
 import (
+	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 
 	"github.com/dnaka91/go-vmcparser/osc"
 )
 
+// stringError keeps track of the remaining data when reading a string failed, so the failure can
+// be located afterwards.
+type stringError struct {
+	remaining int
+	err       error
+}
+
+var _ error = (*stringError)(nil)
+
+func (e stringError) Error() string {
+	return fmt.Sprintf("failed reading string: %v", e.err)
+}
+
+func (e stringError) Unwrap() error {
+	return e.err
+}
+
 func getString(buf []byte) ([]byte, []byte, error) {
 	value, newBuf, err := osc.ReadString(buf)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed reading string: %w", err)
+		return nil, nil, stringError{remaining: len(buf), err: err}
 	}
 
 	return value, newBuf, nil
@@ -50,3 +69,51 @@ func getVec4(buf []byte) Vec4 {
 		W: math.Float32frombits(binary.BigEndian.Uint32(buf[12:16])),
 	}
 }
+
+// remainingData extracts the amount of data, that was left when the given error happened.
+func remainingData(err error) (int, bool) {
+	var lengthErr InvalidBufferLengthError
+	if errors.As(err, &lengthErr) {
+		return lengthErr.Length, true
+	}
+
+	var stringErr stringError
+	if errors.As(err, &stringErr) {
+		return stringErr.remaining, true
+	}
+
+	return 0, false
+}
+
+// argumentIndex finds the index of the argument, that contains the given position in the data. All
+// VMC messages only use int, float and string arguments, so any other type tags aren't considered.
+func argumentIndex(tags, data []byte, pos int) int {
+	const padSize = 4
+
+	offset := 0
+
+	for idx, tag := range tags {
+		if offset >= len(data) {
+			return idx
+		}
+
+		size := 4
+
+		if tag == osc.TypeTagString {
+			end := bytes.IndexByte(data[offset:], 0)
+			if end == -1 {
+				return idx
+			}
+
+			size = end + padSize - end%padSize
+		}
+
+		if pos < offset+size {
+			return idx
+		}
+
+		offset += size
+	}
+
+	return -1
+}
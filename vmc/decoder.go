@@ -0,0 +1,189 @@
+package vmc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+)
+
+// Decoder parses raw VMC messages like ParseMessage, but reuses the message values between calls.
+// Once each message type was decoded, no further allocations happen, which takes considerable
+// pressure off the garbage collector when handling large amounts of messages.
+//
+// In turn, the returned message is only valid until the next call to Decode, as the next message
+// of the same type overwrites it. Messages, that need to be kept around for longer, must be copied.
+//
+// The zero value is ready to use. A decoder must not be used concurrently.
+type Decoder struct {
+	// PassUnknown enables the pass-through of messages with unknown addresses. Instead of failing
+	// with ErrUnknownAddress, those messages are returned as UnknownMessage, with all the
+	// arguments decoded into generic values.
+	PassUnknown bool
+	// Validator enables semantic checks of all decoded messages. Messages with issues fail with a
+	// ValidationError, which still holds the decoded message, so it can be repaired if desired.
+	Validator *Validator
+
+	parsers  map[string]ParseFunc
+	unknown  *UnknownMessage
+	messages messageStorage
+}
+
+// ParseFunc parses the arguments of a single message. It receives the type tags and the raw data of
+// all arguments, following the type tags.
+//
+// Arguments can be read with the helpers of the osc package, like osc.ReadArguments or
+// osc.ReadString. The returned errors are wrapped into an osc.ParseError by the decoder. A nil
+// message without an error is rejected with ErrNoMessage, including nil pointers of a message type.
+type ParseFunc func(tags, data []byte) (Message, error)
+
+// ErrNoMessage happens when a registered ParseFunc returns neither a message nor an error.
+var ErrNoMessage = errors.New("parser returned no message")
+
+// Extension can be embedded into custom message types, to make them implement the Message
+// interface. Such messages can then be returned from a ParseFunc.
+//
+// The protocol version of extensions is unknown, but can be overridden by defining a custom
+// ProtocolVersion method.
+type Extension struct{}
+
+func (Extension) isMessage() {}
+
+// ProtocolVersion is unknown for extensions, as they're not part of the VMC protocol.
+func (Extension) ProtocolVersion() ProtocolVersion {
+	return ProtocolVersionUnknown
+}
+
+// Register adds a parser for the given address. This allows to handle custom messages, or to
+// override the parsing of any of the built-in messages. Registered addresses take part in the
+// address filtering, the same way as the built-in ones.
+//
+// A nil parser removes a previous registration. Parsers must be registered before the decoder is
+// used.
+func (d *Decoder) Register(address string, parse ParseFunc) {
+	if parse == nil {
+		delete(d.parsers, address)
+
+		return
+	}
+
+	if d.parsers == nil {
+		d.parsers = make(map[string]ParseFunc)
+	}
+
+	d.parsers[address] = parse
+}
+
+// Decode parses the raw data into one of the known VMC messages, the same way as ParseMessage does.
+// The returned message is owned by the decoder and only valid until the next call to Decode.
+func (d *Decoder) Decode(data []byte, addressFilters ...string) (Message, error) {
+	raw := data
+
+	address, newData, err := getString(data)
+	if err != nil {
+		return nil, osc.ParseError{Offset: 0, Argument: -1, Path: nil, Address: "", Err: err}
+	}
+	data = newData
+
+	if !filterAddress(address, addressFilters) {
+		return nil, ErrFiltered
+	}
+
+	tags, newData, err := getTypeTags(data)
+	if err != nil {
+		return nil, osc.ParseError{
+			Offset:   len(raw) - len(data),
+			Argument: -1,
+			Path:     nil,
+			Address:  string(address),
+			Err:      err,
+		}
+	}
+	data = newData
+
+	message, err := d.parseArguments(address, tags, data)
+	if errors.Is(err, ErrUnknownAddress) {
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, argumentError(raw, address, tags, data, err)
+	}
+
+	if d.Validator != nil {
+		if err := d.Validator.Validate(message); err != nil {
+			return nil, err
+		}
+	}
+
+	return message, nil
+}
+
+func (d *Decoder) parseArguments(address, tags, data []byte) (Message, error) {
+	if parse, ok := d.parsers[string(address)]; ok {
+		message, err := parse(tags, data)
+		if err == nil && isNil(message) {
+			return nil, ErrNoMessage
+		}
+
+		return message, err
+	}
+
+	if message, ok, err := d.messages.decode(address, tags, data); ok {
+		return message, err
+	}
+
+	if d.PassUnknown {
+		return d.decodeUnknown(address, tags, data)
+	}
+
+	return nil, ErrUnknownAddress
+}
+
+// isNil tells whether the message is nil, or a typed nil pointer that is wrapped in the interface.
+func isNil(message Message) bool {
+	if message == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(message)
+
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
+
+func (d *Decoder) decodeUnknown(address, tags, data []byte) (Message, error) {
+	if d.unknown == nil {
+		d.unknown = &UnknownMessage{Address: nil, TypeTags: nil, Arguments: nil}
+	}
+
+	arguments, _, err := osc.ReadArguments(d.unknown.Arguments[:0], tags, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading arguments: %w", err)
+	}
+
+	*d.unknown = UnknownMessage{
+		Address:   address,
+		TypeTags:  tags,
+		Arguments: arguments,
+	}
+
+	return d.unknown, nil
+}
+
+// decodeInto parses the message into the given storage, allocating a new value only if the storage
+// is still empty.
+func decodeInto[T any, PT interface {
+	*T
+	Message
+}](storage *PT, tags, data []byte, parse func(tags, data []byte, value PT) error) (Message, error) {
+	if *storage == nil {
+		*storage = new(T)
+	}
+
+	if err := parse(tags, data, *storage); err != nil {
+		return nil, err
+	}
+
+	return *storage, nil
+}
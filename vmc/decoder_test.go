@@ -0,0 +1,198 @@
+package vmc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderReusesMessages(t *testing.T) {
+	var decoder vmc.Decoder
+
+	first, err := decoder.Decode([]byte("/VMC/Ext/Blend/Val\x00\x00,sf\x00tst\x00\x40\xa0\x00\x00"))
+	require.NoError(t, err)
+	assert.Equal(t, &vmc.BlendShapeProxyValue{Name: []byte("tst"), Value: 5}, first)
+
+	second, err := decoder.Decode([]byte("/VMC/Ext/Blend/Val\x00\x00,sf\x00abc\x00\x3f\x80\x00\x00"))
+	require.NoError(t, err)
+	assert.Equal(t, &vmc.BlendShapeProxyValue{Name: []byte("abc"), Value: 1}, second)
+	assert.Same(t, first, second)
+}
+
+func TestDecoderOptionalFields(t *testing.T) {
+	var decoder vmc.Decoder
+
+	msg, err := decoder.Decode([]byte("/VMC/Ext/Rcv\x00\x00\x00\x00,iis\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x1f\x90127.0.0.1\x00\x00\x00"))
+	require.NoError(t, err)
+
+	assert.Equal(t, &vmc.ReceiveEnable{
+		Enable:    true,
+		Port:      8080,
+		IPAddress: vmc.Some([]byte("127.0.0.1")),
+		Version:   vmc.ProtocolV2_7,
+	}, msg)
+
+	msg, err = decoder.Decode([]byte("/VMC/Ext/Rcv\x00\x00\x00\x00,ii\x00\x00\x00\x00\x01\x00\x00\x1f\x90"))
+	require.NoError(t, err)
+	assert.Equal(t, &vmc.ReceiveEnable{
+		Enable:    true,
+		Port:      8080,
+		IPAddress: vmc.None[[]byte](),
+		Version:   vmc.ProtocolV2_4,
+	}, msg)
+}
+
+func TestDecoderAllocs(t *testing.T) {
+	var decoder vmc.Decoder
+
+	for _, bench := range benchMessages() {
+		input := bench.input
+
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = decoder.Decode(input)
+		})
+		if allocs != 0 {
+			t.Errorf("%s: got %v allocations, expected none", bench.name, allocs)
+		}
+	}
+}
+
+func TestDecoderPassUnknown(t *testing.T) {
+	input := []byte("/VSeeFace/Ext\x00\x00\x00,sif\x00\x00\x00\x00tst\x00\x00\x00\x00\x05\x3f\x80\x00\x00")
+
+	_, err := vmc.ParseMessage(input)
+	assert.ErrorIs(t, err, vmc.ErrUnknownAddress)
+
+	decoder := vmc.Decoder{PassUnknown: true}
+
+	msg, err := decoder.Decode(input)
+	require.NoError(t, err)
+	assert.Equal(t, &vmc.UnknownMessage{
+		Address:   []byte("/VSeeFace/Ext"),
+		TypeTags:  []byte("sif"),
+		Arguments: []interface{}{[]byte("tst"), int32(5), float32(1)},
+	}, msg)
+	assert.Equal(t, vmc.ProtocolVersionUnknown, msg.ProtocolVersion())
+
+	_, err = decoder.Decode(input, vmc.AddressBoneTransform)
+	assert.ErrorIs(t, err, vmc.ErrFiltered)
+}
+
+func TestParseMessageUnknown(t *testing.T) {
+	msg, err := vmc.ParseMessageUnknown([]byte("/VSeeFace/Ext\x00\x00\x00,i\x00\x00\x00\x00\x00\x07"))
+	require.NoError(t, err)
+	assert.Equal(t, &vmc.UnknownMessage{
+		Address:   []byte("/VSeeFace/Ext"),
+		TypeTags:  []byte("i"),
+		Arguments: []interface{}{int32(7)},
+	}, msg)
+
+	// Known messages are parsed as usual.
+	msg, err = vmc.ParseMessageUnknown([]byte("/VMC/Ext/T\x00\x00,f\x00\x00\x3f\x80\x00\x00"))
+	require.NoError(t, err)
+	assert.Equal(t, &vmc.RelativeTime{Time: 1}, msg)
+}
+
+func TestDecoderPassUnknownError(t *testing.T) {
+	decoder := vmc.Decoder{PassUnknown: true}
+
+	_, err := decoder.Decode([]byte("/custom\x00,fi\x00\x3f\x80\x00\x00\x00\x00"))
+	assert.ErrorIs(t, err, osc.ErrIntTooShort)
+
+	var parseErr osc.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, osc.ParseError{
+		Offset:   16,
+		Argument: 1,
+		Address:  "/custom",
+		Err:      parseErr.Err,
+	}, parseErr)
+}
+
+type gaze struct {
+	vmc.Extension
+	Yaw   float32
+	Pitch float32
+}
+
+func parseGaze(tags, data []byte) (vmc.Message, error) {
+	args, _, err := osc.ReadArguments(nil, tags, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(tags) != "ff" {
+		return nil, vmc.InvalidTypeTagsError{Found: tags, Expected: []string{"ff"}}
+	}
+
+	return &gaze{Yaw: args[0].(float32), Pitch: args[1].(float32)}, nil
+}
+
+func TestDecoderRegister(t *testing.T) {
+	input := []byte("/Custom/Gaze\x00\x00\x00\x00,ff\x00\x3f\x80\x00\x00\x40\x00\x00\x00")
+
+	var decoder vmc.Decoder
+	decoder.Register("/Custom/Gaze", parseGaze)
+
+	msg, err := decoder.Decode(input)
+	require.NoError(t, err)
+	assert.Equal(t, &gaze{Yaw: 1, Pitch: 2}, msg)
+	assert.Equal(t, vmc.ProtocolVersionUnknown, msg.ProtocolVersion())
+
+	_, err = decoder.Decode(input, vmc.AddressBoneTransform)
+	assert.ErrorIs(t, err, vmc.ErrFiltered)
+
+	_, err = decoder.Decode([]byte("/Custom/Gaze\x00\x00\x00\x00,f\x00\x00\x3f\x80\x00\x00"))
+	assert.ErrorAs(t, err, &vmc.InvalidTypeTagsError{})
+
+	var parseErr osc.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "/Custom/Gaze", parseErr.Address)
+
+	decoder.Register("/Custom/Gaze", nil)
+
+	_, err = decoder.Decode(input)
+	assert.ErrorIs(t, err, vmc.ErrUnknownAddress)
+}
+
+func TestDecoderRegisterOverride(t *testing.T) {
+	var decoder vmc.Decoder
+	decoder.Register(vmc.AddressRelativeTime, func(tags, data []byte) (vmc.Message, error) {
+		return &vmc.RelativeTime{Time: 42}, nil
+	})
+
+	msg, err := decoder.Decode([]byte("/VMC/Ext/T\x00\x00,f\x00\x00\x40\xa0\x00\x00"))
+	require.NoError(t, err)
+	assert.Equal(t, &vmc.RelativeTime{Time: 42}, msg)
+}
+
+func TestDecoderRegisterNoMessage(t *testing.T) {
+	var decoder vmc.Decoder
+	decoder.Register("/Custom/Nil", func(tags, data []byte) (vmc.Message, error) {
+		var msg vmc.Message
+
+		return msg, nil
+	})
+
+	msg, err := decoder.Decode([]byte("/Custom/Nil\x00,\x00\x00\x00"))
+	assert.Nil(t, msg)
+	assert.ErrorIs(t, err, vmc.ErrNoMessage)
+
+	var parseErr osc.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, "/Custom/Nil", parseErr.Address)
+
+	// A nil pointer of a message type is a non-nil interface, but still no message.
+	decoder.Register("/Custom/Nil", func(tags, data []byte) (vmc.Message, error) {
+		var msg *gaze
+
+		return msg, nil
+	})
+
+	msg, err = decoder.Decode([]byte("/Custom/Nil\x00,\x00\x00\x00"))
+	assert.Nil(t, msg)
+	assert.ErrorIs(t, err, vmc.ErrNoMessage)
+}
@@ -28,7 +28,32 @@ func ExampleParseMessage() {
 		panic("message must be the `Available` VMC message")
 	}
 
-	// Output: &{true Calibrated MrNormal <nil>}
+	// Output: Available { Loaded: true, CalibrationState: Calibrated, CalibrationMode: MrNormal, TrackingStatus: <none>, Version: V2.5 }
+}
+
+func ExampleDecoder() {
+	// A VMC "Blend Shape Proxy Value" message in raw form.
+	raw := []byte("/VMC/Ext/Blend/Val\x00\x00,sf\x00Joy\x00\x3f\x80\x00\x00")
+
+	// The decoder can be reused for any amount of messages, and doesn't allocate anymore once all
+	// messages types were seen.
+	var decoder vmc.Decoder
+
+	for i := 0; i < 2; i++ {
+		message, err := decoder.Decode(raw)
+		if err != nil {
+			panic(err)
+		}
+
+		// The message is only valid until the next call to `Decode`.
+		if m, ok := message.(*vmc.BlendShapeProxyValue); ok {
+			fmt.Printf("%s: %v\n", m.Name, m.Value)
+		}
+	}
+
+	// Output:
+	// Joy: 1
+	// Joy: 1
 }
 
 func Example_udpServer() {
@@ -0,0 +1,49 @@
+package vmc
+
+import (
+	"strconv"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+)
+
+// Encodable is implemented by all messages of the VMC protocol, which can be turned back into their
+// OSC encoding. This allows to forward modified messages, or to send messages altogether.
+//
+// Custom messages, as returned by registered parsers, or unknown messages don't implement it.
+type Encodable interface {
+	Message
+	// AppendMessage appends the message in its OSC encoding to the buffer.
+	AppendMessage(buf []byte) []byte
+}
+
+func appendBool(buf []byte, value bool) []byte {
+	if value {
+		return osc.AppendInt(buf, 1)
+	}
+
+	return osc.AppendInt(buf, 0)
+}
+
+func appendVec3(buf []byte, value Vec3) []byte {
+	buf = osc.AppendFloat(buf, value.X)
+	buf = osc.AppendFloat(buf, value.Y)
+
+	return osc.AppendFloat(buf, value.Z)
+}
+
+func appendVec4(buf []byte, value Vec4) []byte {
+	buf = osc.AppendFloat(buf, value.X)
+	buf = osc.AppendFloat(buf, value.Y)
+	buf = osc.AppendFloat(buf, value.Z)
+
+	return osc.AppendFloat(buf, value.W)
+}
+
+// quoteOptional formats an optional string field for the String methods of messages.
+func quoteOptional(value Optional[[]byte]) string {
+	if !value.Valid {
+		return value.String()
+	}
+
+	return strconv.Quote(string(value.Value))
+}
@@ -0,0 +1,288 @@
+package vmc
+
+import "fmt"
+
+// VRM0Preset is one of the blend shape presets of VRM 0.x avatars.
+type VRM0Preset uint8
+
+// Possible values for the VRM 0.x presets.
+const (
+	VRM0Neutral VRM0Preset = iota
+	VRM0A
+	VRM0I
+	VRM0U
+	VRM0E
+	VRM0O
+	VRM0Blink
+	VRM0Joy
+	VRM0Angry
+	VRM0Sorrow
+	VRM0Fun
+	VRM0LookUp
+	VRM0LookDown
+	VRM0LookLeft
+	VRM0LookRight
+	VRM0BlinkLeft
+	VRM0BlinkRight
+)
+
+// String returns the name of the preset, as used in BlendShapeProxyValue messages.
+func (p VRM0Preset) String() string {
+	switch p {
+	case VRM0Neutral:
+		return "Neutral"
+	case VRM0A:
+		return "A"
+	case VRM0I:
+		return "I"
+	case VRM0U:
+		return "U"
+	case VRM0E:
+		return "E"
+	case VRM0O:
+		return "O"
+	case VRM0Blink:
+		return "Blink"
+	case VRM0Joy:
+		return "Joy"
+	case VRM0Angry:
+		return "Angry"
+	case VRM0Sorrow:
+		return "Sorrow"
+	case VRM0Fun:
+		return "Fun"
+	case VRM0LookUp:
+		return "LookUp"
+	case VRM0LookDown:
+		return "LookDown"
+	case VRM0LookLeft:
+		return "LookLeft"
+	case VRM0LookRight:
+		return "LookRight"
+	case VRM0BlinkLeft:
+		return "Blink_L"
+	case VRM0BlinkRight:
+		return "Blink_R"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(p))
+	}
+}
+
+// VRM1 returns the VRM 1.0 expression, that replaced the preset.
+func (p VRM0Preset) VRM1() VRM1Expression {
+	switch p {
+	case VRM0Neutral:
+		return VRM1Neutral
+	case VRM0A:
+		return VRM1Aa
+	case VRM0I:
+		return VRM1Ih
+	case VRM0U:
+		return VRM1Ou
+	case VRM0E:
+		return VRM1Ee
+	case VRM0O:
+		return VRM1Oh
+	case VRM0Blink:
+		return VRM1Blink
+	case VRM0Joy:
+		return VRM1Happy
+	case VRM0Angry:
+		return VRM1Angry
+	case VRM0Sorrow:
+		return VRM1Sad
+	case VRM0Fun:
+		return VRM1Relaxed
+	case VRM0LookUp:
+		return VRM1LookUp
+	case VRM0LookDown:
+		return VRM1LookDown
+	case VRM0LookLeft:
+		return VRM1LookLeft
+	case VRM0LookRight:
+		return VRM1LookRight
+	case VRM0BlinkLeft:
+		return VRM1BlinkLeft
+	case VRM0BlinkRight:
+		return VRM1BlinkRight
+	default:
+		return VRM1Neutral
+	}
+}
+
+// ARKit returns the ARKit blend shapes, that make up the preset. See VRM1Expression.ARKit for
+// details.
+func (p VRM0Preset) ARKit() []ARKitWeight {
+	return p.VRM1().ARKit()
+}
+
+// VRM1Expression is one of the preset expressions of VRM 1.0 avatars.
+type VRM1Expression uint8
+
+// Possible values for the VRM 1.0 expressions.
+const (
+	VRM1Neutral VRM1Expression = iota
+	VRM1Aa
+	VRM1Ih
+	VRM1Ou
+	VRM1Ee
+	VRM1Oh
+	VRM1Blink
+	VRM1Happy
+	VRM1Angry
+	VRM1Sad
+	VRM1Relaxed
+	VRM1LookUp
+	VRM1LookDown
+	VRM1LookLeft
+	VRM1LookRight
+	VRM1BlinkLeft
+	VRM1BlinkRight
+	VRM1Surprised
+)
+
+// String returns the name of the expression, as used in BlendShapeProxyValue messages.
+func (e VRM1Expression) String() string {
+	switch e {
+	case VRM1Neutral:
+		return "neutral"
+	case VRM1Aa:
+		return "aa"
+	case VRM1Ih:
+		return "ih"
+	case VRM1Ou:
+		return "ou"
+	case VRM1Ee:
+		return "ee"
+	case VRM1Oh:
+		return "oh"
+	case VRM1Blink:
+		return "blink"
+	case VRM1Happy:
+		return "happy"
+	case VRM1Angry:
+		return "angry"
+	case VRM1Sad:
+		return "sad"
+	case VRM1Relaxed:
+		return "relaxed"
+	case VRM1LookUp:
+		return "lookUp"
+	case VRM1LookDown:
+		return "lookDown"
+	case VRM1LookLeft:
+		return "lookLeft"
+	case VRM1LookRight:
+		return "lookRight"
+	case VRM1BlinkLeft:
+		return "blinkLeft"
+	case VRM1BlinkRight:
+		return "blinkRight"
+	case VRM1Surprised:
+		return "surprised"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(e))
+	}
+}
+
+// ARKit returns the ARKit blend shapes, that make up the expression. The weights of each
+// expression add up to 1, so that fully activated shapes result in a fully activated expression.
+// Neutral and unknown expressions have no ARKit counterpart and return nil.
+//
+// The conversion is an approximation, as ARKit describes individual muscle movements rather than
+// emotions or visemes.
+func (e VRM1Expression) ARKit() []ARKitWeight {
+	switch e {
+	case VRM1Aa:
+		return []ARKitWeight{
+			{Shape: ARKitJawOpen, Weight: 1},
+		}
+	case VRM1Ih:
+		return []ARKitWeight{
+			{Shape: ARKitJawOpen, Weight: 0.2},
+			{Shape: ARKitMouthStretchLeft, Weight: 0.4},
+			{Shape: ARKitMouthStretchRight, Weight: 0.4},
+		}
+	case VRM1Ou:
+		return []ARKitWeight{
+			{Shape: ARKitJawOpen, Weight: 0.2},
+			{Shape: ARKitMouthPucker, Weight: 0.8},
+		}
+	case VRM1Ee:
+		return []ARKitWeight{
+			{Shape: ARKitJawOpen, Weight: 0.4},
+			{Shape: ARKitMouthStretchLeft, Weight: 0.3},
+			{Shape: ARKitMouthStretchRight, Weight: 0.3},
+		}
+	case VRM1Oh:
+		return []ARKitWeight{
+			{Shape: ARKitJawOpen, Weight: 0.3},
+			{Shape: ARKitMouthFunnel, Weight: 0.7},
+		}
+	case VRM1Blink:
+		return []ARKitWeight{
+			{Shape: ARKitEyeBlinkLeft, Weight: 0.5},
+			{Shape: ARKitEyeBlinkRight, Weight: 0.5},
+		}
+	case VRM1Happy:
+		return []ARKitWeight{
+			{Shape: ARKitMouthSmileLeft, Weight: 0.5},
+			{Shape: ARKitMouthSmileRight, Weight: 0.5},
+		}
+	case VRM1Angry:
+		return []ARKitWeight{
+			{Shape: ARKitBrowDownLeft, Weight: 0.5},
+			{Shape: ARKitBrowDownRight, Weight: 0.5},
+		}
+	case VRM1Sad:
+		return []ARKitWeight{
+			{Shape: ARKitBrowInnerUp, Weight: 0.5},
+			{Shape: ARKitMouthFrownLeft, Weight: 0.25},
+			{Shape: ARKitMouthFrownRight, Weight: 0.25},
+		}
+	case VRM1Relaxed:
+		return []ARKitWeight{
+			{Shape: ARKitMouthSmileLeft, Weight: 0.3},
+			{Shape: ARKitMouthSmileRight, Weight: 0.3},
+			{Shape: ARKitCheekSquintLeft, Weight: 0.2},
+			{Shape: ARKitCheekSquintRight, Weight: 0.2},
+		}
+	case VRM1LookUp:
+		return []ARKitWeight{
+			{Shape: ARKitEyeLookUpLeft, Weight: 0.5},
+			{Shape: ARKitEyeLookUpRight, Weight: 0.5},
+		}
+	case VRM1LookDown:
+		return []ARKitWeight{
+			{Shape: ARKitEyeLookDownLeft, Weight: 0.5},
+			{Shape: ARKitEyeLookDownRight, Weight: 0.5},
+		}
+	case VRM1LookLeft:
+		return []ARKitWeight{
+			{Shape: ARKitEyeLookOutLeft, Weight: 0.5},
+			{Shape: ARKitEyeLookInRight, Weight: 0.5},
+		}
+	case VRM1LookRight:
+		return []ARKitWeight{
+			{Shape: ARKitEyeLookInLeft, Weight: 0.5},
+			{Shape: ARKitEyeLookOutRight, Weight: 0.5},
+		}
+	case VRM1BlinkLeft:
+		return []ARKitWeight{
+			{Shape: ARKitEyeBlinkLeft, Weight: 1},
+		}
+	case VRM1BlinkRight:
+		return []ARKitWeight{
+			{Shape: ARKitEyeBlinkRight, Weight: 1},
+		}
+	case VRM1Surprised:
+		return []ARKitWeight{
+			{Shape: ARKitBrowOuterUpLeft, Weight: 0.25},
+			{Shape: ARKitBrowOuterUpRight, Weight: 0.25},
+			{Shape: ARKitEyeWideLeft, Weight: 0.25},
+			{Shape: ARKitEyeWideRight, Weight: 0.25},
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+package vmc
+
+import "sort"
+
+// Pose is the position and rotation of a single transform, like the avatar root or a bone.
+type Pose struct {
+	Position   Vec3
+	Quaternion Vec4
+}
+
+// Interpolate blends between p and o, where t=0 yields p and t=1 yields o. Positions are
+// interpolated linearly and rotations spherically.
+func (p Pose) Interpolate(o Pose, t float32) Pose {
+	return Pose{
+		Position:   p.Position.Lerp(o.Position, t),
+		Quaternion: p.Quaternion.Slerp(o.Quaternion, t),
+	}
+}
+
+// Frame is the combined state of an avatar, assembled from the individual messages that were
+// received up to a BlendShapeProxyApply message.
+//
+// Bones and blend shapes are keyed by their name. The parsed messages only reference the original
+// packet data, therefore the names are copied when they are added to a frame.
+type Frame struct {
+	Time        float32            // Time is the last received relative time.
+	RootName    string             // RootName is the name of the last received root transform.
+	Root        Pose               // Root is the last received root transform.
+	Bones       map[string]Pose    // Bones contains the last received transform of each bone.
+	BlendShapes map[string]float32 // BlendShapes contains the last received blend shape values.
+}
+
+// Clone creates a deep copy of the frame, that is safe to keep around while the original frame is
+// further modified.
+func (f *Frame) Clone() Frame {
+	clone := Frame{
+		Time:        f.Time,
+		RootName:    f.RootName,
+		Root:        f.Root,
+		Bones:       make(map[string]Pose, len(f.Bones)),
+		BlendShapes: make(map[string]float32, len(f.BlendShapes)),
+	}
+
+	for name, pose := range f.Bones {
+		clone.Bones[name] = pose
+	}
+
+	for name, value := range f.BlendShapes {
+		clone.BlendShapes[name] = value
+	}
+
+	return clone
+}
+
+// FrameBuilder collects avatar related messages into frames.
+//
+// VMC senders only transmit the state of bones and blend shapes, not any changes. Therefore, the
+// state is kept across frames, and values that weren't part of the latest update keep their
+// previous value.
+//
+// The zero value is ready to use.
+type FrameBuilder struct {
+	frame Frame
+}
+
+// Add applies the message to the frame under construction. It returns true if the message
+// completed the frame, which is the case for BlendShapeProxyApply messages. Messages that don't
+// describe the avatar state are ignored.
+func (b *FrameBuilder) Add(msg Message) bool {
+	switch m := msg.(type) {
+	case *RelativeTime:
+		b.frame.Time = m.Time
+	case *RootTransform:
+		// Comparing first avoids allocating the name for each message, as it rarely changes.
+		if b.frame.RootName != string(m.Name) {
+			b.frame.RootName = string(m.Name)
+		}
+
+		b.frame.Root = Pose{Position: m.Position, Quaternion: m.Quaternion}
+	case *BoneTransform:
+		if b.frame.Bones == nil {
+			b.frame.Bones = make(map[string]Pose)
+		}
+
+		b.frame.Bones[string(m.Name)] = Pose{Position: m.Position, Quaternion: m.Quaternion}
+	case *BlendShapeProxyValue:
+		if b.frame.BlendShapes == nil {
+			b.frame.BlendShapes = make(map[string]float32)
+		}
+
+		b.frame.BlendShapes[string(m.Name)] = m.Value
+	case *BlendShapeProxyApply:
+		return true
+	}
+
+	return false
+}
+
+// Frame returns the frame under construction. It is owned by the builder and further modified by
+// following calls to Add. Use Frame.Clone to keep a copy.
+func (b *FrameBuilder) Frame() *Frame {
+	return &b.frame
+}
+
+// Messages turns the frame back into the messages, that describe it. The root transform, all bones
+// and all blend shapes are emitted in name order, preceded by the relative time and followed by a
+// BlendShapeProxyApply message. The root transform is named "root", if the frame has no root name.
+//
+// The emitted messages are only valid during the callback, as they're reused between calls.
+func (f *Frame) Messages(emit func(msg Encodable)) {
+	rootName := f.RootName
+	if rootName == "" {
+		rootName = "root"
+	}
+
+	emit(&RelativeTime{Time: f.Time})
+	emit(&RootTransform{
+		Name:       []byte(rootName),
+		Position:   f.Root.Position,
+		Quaternion: f.Root.Quaternion,
+		Scale:      None[Vec3](),
+		Offset:     None[Vec3](),
+		Version:    ProtocolVersionUnknown,
+	})
+
+	bone := BoneTransform{Name: nil, Position: Vec3{}, Quaternion: Vec4{}}
+
+	for _, name := range sortedKeys(f.Bones) {
+		pose := f.Bones[name]
+		bone = BoneTransform{
+			Name:       append(bone.Name[:0], name...),
+			Position:   pose.Position,
+			Quaternion: pose.Quaternion,
+		}
+		emit(&bone)
+	}
+
+	blendShape := BlendShapeProxyValue{Name: nil, Value: 0}
+
+	for _, name := range sortedKeys(f.BlendShapes) {
+		blendShape = BlendShapeProxyValue{
+			Name:  append(blendShape.Name[:0], name...),
+			Value: f.BlendShapes[name],
+		}
+		emit(&blendShape)
+	}
+
+	emit(&BlendShapeProxyApply{})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
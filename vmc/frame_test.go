@@ -0,0 +1,91 @@
+package vmc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameBuilder(t *testing.T) {
+	var builder vmc.FrameBuilder
+
+	assert.False(t, builder.Add(&vmc.RelativeTime{Time: 1.5}))
+	assert.False(t, builder.Add(&vmc.RootTransform{
+		Name:       []byte("root"),
+		Position:   vmc.Vec3{X: 1, Y: 2, Z: 3},
+		Quaternion: vmc.Vec4{W: 1},
+	}))
+	assert.False(t, builder.Add(&vmc.BoneTransform{
+		Name:       []byte("Hips"),
+		Position:   vmc.Vec3{Y: 1},
+		Quaternion: vmc.Vec4{W: 1},
+	}))
+	assert.False(t, builder.Add(&vmc.BlendShapeProxyValue{Name: []byte("A"), Value: 0.5}))
+	assert.False(t, builder.Add(&vmc.KeyboardInput{Active: true, Name: []byte("a"), KeyCode: 65}))
+	assert.True(t, builder.Add(&vmc.BlendShapeProxyApply{}))
+
+	want := vmc.Frame{
+		Time:     1.5,
+		RootName: "root",
+		Root:     vmc.Pose{Position: vmc.Vec3{X: 1, Y: 2, Z: 3}, Quaternion: vmc.Vec4{W: 1}},
+		Bones: map[string]vmc.Pose{
+			"Hips": {Position: vmc.Vec3{Y: 1}, Quaternion: vmc.Vec4{W: 1}},
+		},
+		BlendShapes: map[string]float32{"A": 0.5},
+	}
+	assert.Equal(t, &want, builder.Frame())
+
+	clone := builder.Frame().Clone()
+
+	// State is kept across frames, and only updated values change.
+	assert.False(t, builder.Add(&vmc.BlendShapeProxyValue{Name: []byte("A"), Value: 1}))
+	assert.True(t, builder.Add(&vmc.BlendShapeProxyApply{}))
+	assert.Equal(t, float32(1), builder.Frame().BlendShapes["A"])
+	assert.Equal(t, vmc.Pose{Position: vmc.Vec3{Y: 1}, Quaternion: vmc.Vec4{W: 1}}, builder.Frame().Bones["Hips"])
+
+	assert.Equal(t, want, clone)
+}
+
+func TestFrameMessages(t *testing.T) {
+	frame := vmc.Frame{
+		Time:     2.5,
+		RootName: "Avatar",
+		Root:     vmc.Pose{Position: vmc.Vec3{X: 1}, Quaternion: vmc.Vec4{W: 1}},
+		Bones: map[string]vmc.Pose{
+			"Hips":  {Position: vmc.Vec3{Y: 1}, Quaternion: vmc.Vec4{W: 1}},
+			"Chest": {Position: vmc.Vec3{Y: 2}, Quaternion: vmc.Vec4{W: 1}},
+		},
+		BlendShapes: map[string]float32{"A": 0.5, "Blink": 1},
+	}
+
+	var (
+		builder   vmc.FrameBuilder
+		addresses []string
+		completed bool
+	)
+
+	frame.Messages(func(msg vmc.Encodable) {
+		raw := msg.AppendMessage(nil)
+
+		parsed, err := vmc.ParseMessage(raw)
+		require.NoError(t, err)
+
+		addresses = append(addresses, string(raw[:bytes.IndexByte(raw, 0)]))
+		completed = builder.Add(parsed)
+	})
+
+	assert.True(t, completed)
+	assert.Equal(t, &frame, builder.Frame())
+	assert.Equal(t, []string{
+		vmc.AddressRelativeTime,
+		vmc.AddressRootTransform,
+		vmc.AddressBoneTransform,
+		vmc.AddressBoneTransform,
+		vmc.AddressBlendShapeProxyValue,
+		vmc.AddressBlendShapeProxyValue,
+		vmc.AddressBlendShapeProxyApply,
+	}, addresses)
+}
@@ -0,0 +1,59 @@
+package vmc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+)
+
+func FuzzParseMessage(f *testing.F) {
+	seeds := []string{
+		"/VMC/Ext/OK\x00,i\x00\x00\x00\x00\x00\x01",
+		"/VMC/Ext/OK\x00,iii\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x03\x00\x00\x00\x01",
+		"/VMC/Ext/OK\x00,iiii\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x03\x00\x00\x00\x01\x00\x00\x00\x01",
+		"/VMC/Ext/T\x00\x00,f\x00\x00\x40\xa0\x00\x00",
+		"/VMC/Ext/Root/Pos\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a",
+		"/VMC/Ext/Root/Pos\x00\x00\x00,sfffffffffffff\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a\x40\x46\x66\x66\x40\x4c\xcc\xcd\x40\x53\x33\x33\x40\x83\x33\x33\x40\x86\x66\x66\x40\x89\x99\x9a",
+		"/VMC/Ext/Bone/Pos\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a",
+		"/VMC/Ext/Blend/Val\x00\x00,sf\x00tst\x00\x40\xa0\x00\x00",
+		"/VMC/Ext/Blend/Apply\x00\x00\x00\x00,\x00\x00\x00",
+		"/VMC/Ext/Cam\x00\x00\x00\x00,sffffffff\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a\x40\xa0\x00\x00",
+		"/VMC/Ext/Con\x00\x00\x00\x00,isiiifff\x00\x00\x00\x00\x00\x00\x01tst\x00\x00\x00\x00\x01\x00\x00\x00\x01\x00\x00\x00\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66",
+		"/VMC/Ext/Key\x00\x00\x00\x00,isi\x00\x00\x00\x00\x00\x00\x00\x01tst\x00\x00\x00\x00\x05",
+		"/VMC/Ext/Midi/Note\x00\x00,iiif\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x01\x00\x00\x00\x02\x3f\x8c\xcc\xcd",
+		"/VMC/Ext/Midi/CC/Val\x00\x00\x00\x00,if\x00\x00\x00\x00\x01\x3f\x8c\xcc\xcd",
+		"/VMC/Ext/Midi/CC/Bit\x00\x00\x00\x00,ii\x00\x00\x00\x00\x01\x00\x00\x00\x01",
+		"/VMC/Ext/Hmd/Pos\x00\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a",
+		"/VMC/Ext/Con/Pos\x00\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a",
+		"/VMC/Ext/Tra/Pos\x00\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a",
+		"/VMC/Ext/Hmd/Pos/Local\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a",
+		"/VMC/Ext/Con/Pos/Local\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a",
+		"/VMC/Ext/Tra/Pos/Local\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a",
+		"/VMC/Ext/Rcv\x00\x00\x00\x00,ii\x00\x00\x00\x00\x01\x00\x00\x1f\x90",
+		"/VMC/Ext/Rcv\x00\x00\x00\x00,iis\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x1f\x90127.0.0.1\x00\x00\x00",
+		"/VMC/Ext/Light\x00\x00,sfffffffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a\x40\x46\x66\x66\x40\x4c\xcc\xcd\x40\x53\x33\x33\x40\x59\x99\x9a",
+		"/VMC/Ext/VRM\x00\x00\x00\x00,ss\x00t01\x00t02\x00",
+		"/VMC/Ext/VRM\x00\x00\x00\x00,sss\x00\x00\x00\x00t01\x00t02\x00t03\x00",
+		"/VMC/Ext/Remote\x00,ss\x00tst\x00{}\x00\x00",
+		"/VMC/Ext/Opt\x00\x00\x00\x00,s\x00\x00tst\x00",
+		"/VMC/Ext/Setting/Color\x00\x00,ffff\x00\x00\x00\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a",
+		"/VMC/Ext/Setting/Win\x00\x00\x00\x00,iiii\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x01\x00\x00\x00\x01\x00\x00\x00\x01",
+		"/VMC/Ext/Config\x00,s\x00\x00tst\x00",
+		"/VMC/Ext/Set/Eye\x00\x00\x00\x00,ifff\x00\x00\x00\x00\x00\x00\x01\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66",
+	}
+
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		message, err := vmc.ParseMessage(data)
+		if err != nil && message != nil {
+			t.Fatalf("got message %v together with error: %v", message, err)
+		}
+
+		if err == nil && message == nil {
+			t.Fatal("got neither message nor error")
+		}
+	})
+}
@@ -0,0 +1,244 @@
+package vmc_test
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "regenerate the golden files in testdata/golden")
+
+const goldenDir = "testdata/golden"
+
+// goldenSample is a single entry of the golden corpus. Each sample is stored as raw packet in a
+// .osc file, next to a .txt file with the textual expectation of the parse result.
+type goldenSample struct {
+	name   string
+	packet []byte
+}
+
+// TestGolden parses every packet of the golden corpus, and compares the result against the stored
+// expectation. With the -update flag, the corpus is written from scratch instead:
+//
+//	go test ./vmc -run TestGolden -update
+func TestGolden(t *testing.T) {
+	samples := goldenSamples()
+
+	if *update {
+		writeGolden(t, samples)
+	}
+
+	for _, sample := range samples {
+		stored, err := os.ReadFile(filepath.Join(goldenDir, sample.name+".osc"))
+		require.NoError(t, err, "%s is missing, regenerate the corpus with -update", sample.name)
+		assert.Equal(t, sample.packet, stored, "%s is outdated, regenerate the corpus with -update", sample.name)
+	}
+
+	files, err := filepath.Glob(filepath.Join(goldenDir, "*.osc"))
+	require.NoError(t, err)
+	assert.Len(t, files, len(samples), "the corpus contains unknown samples")
+
+	for _, file := range files {
+		file := file
+		name := strings.TrimSuffix(filepath.Base(file), ".osc")
+
+		t.Run(name, func(t *testing.T) {
+			packet, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			want, err := os.ReadFile(strings.TrimSuffix(file, ".osc") + ".txt")
+			require.NoError(t, err)
+
+			msg, err := vmc.ParseMessage(packet)
+			assert.Equal(t, string(want), describe(msg, err))
+
+			// Valid packets must encode back into the exact same bytes.
+			if encodable, ok := msg.(vmc.Encodable); ok && err == nil {
+				assert.Equal(t, packet, encodable.AppendMessage(nil))
+			}
+		})
+	}
+}
+
+// describe formats the parse result as textual expectation.
+func describe(msg vmc.Message, err error) string {
+	if err != nil {
+		return fmt.Sprintf("error: %v\n", err)
+	}
+
+	return fmt.Sprintf("%T\n%v\n", msg, msg)
+}
+
+func writeGolden(t *testing.T, samples []goldenSample) {
+	t.Helper()
+
+	require.NoError(t, os.RemoveAll(goldenDir))
+	require.NoError(t, os.MkdirAll(goldenDir, 0o755))
+
+	for _, sample := range samples {
+		path := filepath.Join(goldenDir, sample.name)
+
+		require.NoError(t, os.WriteFile(path+".osc", sample.packet, 0o600))
+		require.NoError(t, os.WriteFile(path+".txt", []byte(describe(vmc.ParseMessage(sample.packet))), 0o600))
+	}
+}
+
+// goldenSamples creates all samples of the corpus, sorted by name. It covers every address and
+// protocol variant, as well as edge cases of the encoding and malformed packets.
+func goldenSamples() []goldenSample {
+	var samples []goldenSample
+
+	add := func(name string, msg vmc.Encodable) {
+		samples = append(samples, goldenSample{name: name, packet: msg.AppendMessage(nil)})
+	}
+
+	raw := func(name string, packet []byte) {
+		samples = append(samples, goldenSample{name: name, packet: packet})
+	}
+
+	pos := vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3}
+	rot := vmc.Vec4{X: 0, Y: 0.7071068, Z: 0, W: 0.7071068}
+
+	// Every address and protocol variant.
+	add("available_v1", &vmc.Available{Loaded: true, Version: vmc.ProtocolV1})
+	add("available_v2_5", &vmc.Available{
+		Loaded:           true,
+		CalibrationState: vmc.Some(vmc.CalibrationStateCalibrating),
+		CalibrationMode:  vmc.Some(vmc.CalibrationModeMrFloorFix),
+		Version:          vmc.ProtocolV2_5,
+	})
+	add("available_v2_7", &vmc.Available{
+		Loaded:           false,
+		CalibrationState: vmc.Some(vmc.CalibrationStateUncalibrated),
+		CalibrationMode:  vmc.Some(vmc.CalibrationModeNormal),
+		TrackingStatus:   vmc.Some(true),
+		Version:          vmc.ProtocolV2_7,
+	})
+	add("relative_time", &vmc.RelativeTime{Time: 12.5})
+	add("root_transform_v2_0", &vmc.RootTransform{Name: []byte("root"), Position: pos, Quaternion: rot})
+	add("root_transform_v2_1", &vmc.RootTransform{
+		Name:       []byte("root"),
+		Position:   pos,
+		Quaternion: rot,
+		Scale:      vmc.Some(vmc.Vec3{X: 1, Y: 1, Z: 1}),
+		Offset:     vmc.Some(vmc.Vec3{X: 0, Y: -0.5, Z: 0}),
+		Version:    vmc.ProtocolV2_1,
+	})
+	add("bone_transform", &vmc.BoneTransform{Name: []byte("Spine"), Position: pos, Quaternion: rot})
+	add("blend_shape_proxy_value", &vmc.BlendShapeProxyValue{Name: []byte("Joy"), Value: 0.75})
+	add("blend_shape_proxy_apply", &vmc.BlendShapeProxyApply{})
+	add("camera_transform", &vmc.CameraTransform{Name: []byte("Camera"), Position: pos, Quaternion: rot, FOV: 60})
+
+	for _, active := range []vmc.ControllerActive{
+		vmc.ControllerActiveRelease,
+		vmc.ControllerActivePress,
+		vmc.ControllerActiveChangeAxis,
+	} {
+		add("controller_input_"+strings.ToLower(active.String()), &vmc.ControllerInput{
+			Active: active,
+			Name:   []byte("Trigger"),
+			IsLeft: true,
+			IsAxis: active == vmc.ControllerActiveChangeAxis,
+			Axis:   vmc.Vec3{X: 0.5, Y: -0.5, Z: 0},
+		})
+	}
+
+	add("keyboard_input", &vmc.KeyboardInput{Active: true, Name: []byte("A"), KeyCode: 65})
+	add("midi_note_input", &vmc.MidiNoteInput{Active: true, Channel: 9, Note: 60, Velocity: 0.8})
+	add("midi_cc_value_input", &vmc.MidiCCValueInput{Knob: 7, Value: 0.25})
+	add("midi_cc_button_input", &vmc.MidiCCButtonInput{Knob: 64, Active: true})
+
+	for _, device := range []vmc.DeviceType{vmc.DeviceTypeHmd, vmc.DeviceTypeController, vmc.DeviceTypeTracker} {
+		for _, local := range []bool{false, true} {
+			name := "device_transform_" + strings.ToLower(device.String())
+			if local {
+				name += "_local"
+			}
+
+			add(name, &vmc.DeviceTransform{
+				Device:     device,
+				Local:      local,
+				Serial:     []byte("LHR-0123ABCD"),
+				Position:   pos,
+				Quaternion: rot,
+			})
+		}
+	}
+
+	add("receive_enable_v2_4", &vmc.ReceiveEnable{Enable: true, Port: 39540, Version: vmc.ProtocolV2_4})
+	add("receive_enable_v2_7", &vmc.ReceiveEnable{
+		Enable:    true,
+		Port:      39540,
+		IPAddress: vmc.Some([]byte("192.168.0.10")),
+		Version:   vmc.ProtocolV2_7,
+	})
+	add("directional_light", &vmc.DirectionalLight{
+		Name:       []byte("Light"),
+		Position:   pos,
+		Quaternion: rot,
+		Color:      vmc.Vec4{X: 1, Y: 0.95, Z: 0.9, W: 1},
+	})
+	add("local_vrm_v2_4", &vmc.LocalVrm{Path: []byte("C:/avatars/a.vrm"), Title: []byte("A"), Version: vmc.ProtocolV2_4})
+	add("local_vrm_v2_7", &vmc.LocalVrm{
+		Path:    []byte("C:/avatars/a.vrm"),
+		Title:   []byte("A"),
+		Hash:    vmc.Some([]byte("0123456789abcdef")),
+		Version: vmc.ProtocolV2_7,
+	})
+	add("remote_vrm", &vmc.RemoteVrm{Service: []byte("VRoidHub"), JSON: []byte(`{"characterModelId":"123"}`)})
+	add("option_string", &vmc.OptionString{Option: []byte("option")})
+	add("background_color", &vmc.BackgroundColor{Color: vmc.Vec4{X: 0, Y: 1, Z: 0, W: 1}})
+	add("window_attribute", &vmc.WindowAttribute{IsTopMost: true, WindowClickThrough: true})
+	add("loaded_setting_path", &vmc.LoadedSettingPath{Path: []byte(`C:\VMC\settings.json`)})
+	add("eye_target", &vmc.EyeTarget{Enable: true, Position: vmc.Vec3{X: 0, Y: 1.5, Z: 2}})
+
+	// Strings of every length modulo 4, as each needs a different amount of padding.
+	for _, name := range []string{"", "A", "Ab", "Abc", "Head", "Spine"} {
+		add(fmt.Sprintf("string_length_%d", len(name)), &vmc.BlendShapeProxyValue{Name: []byte(name), Value: 1})
+	}
+
+	add("string_unicode", &vmc.BlendShapeProxyValue{Name: []byte("あいうえお"), Value: 1})
+	add("string_long", &vmc.OptionString{Option: bytes.Repeat([]byte("0123456789abcdef"), 256)})
+	add("string_max_udp", &vmc.OptionString{Option: bytes.Repeat([]byte("x"), 65_507-len("/VMC/Ext/Opt\x00\x00\x00\x00,s\x00\x00")-4)})
+
+	// Extreme numbers.
+	add("int_limits", &vmc.KeyboardInput{Active: true, Name: []byte("Max"), KeyCode: math.MaxInt32})
+	add("int_negative", &vmc.KeyboardInput{Active: false, Name: []byte("Min"), KeyCode: math.MinInt32})
+	add("float_limits", &vmc.CameraTransform{
+		Name:     []byte("Camera"),
+		Position: vmc.Vec3{X: math.MaxFloat32, Y: -math.MaxFloat32, Z: math.SmallestNonzeroFloat32},
+		Quaternion: vmc.Vec4{
+			X: float32(math.Copysign(0, -1)),
+			Y: float32(math.Inf(1)),
+			Z: float32(math.Inf(-1)),
+			W: 1,
+		},
+		FOV: float32(math.NaN()),
+	})
+
+	// Malformed packets.
+	raw("error_unknown_address", osc.AppendMessage(nil, "/VMC/Ext/Unknown", []byte("i"), osc.AppendInt(nil, 1)))
+	raw("error_type_tags", osc.AppendMessage(nil, vmc.AddressBoneTransform, []byte("sf"),
+		osc.AppendFloat(osc.AppendString(nil, "Head"), 1)))
+	raw("error_truncated", (&vmc.BoneTransform{Name: []byte("Head")}).AppendMessage(nil)[:40])
+	raw("error_missing_terminator", []byte("/VMC/Ext/Blend/Val\x00\x00,sf\x00Joy!"))
+	raw("error_enum_value", osc.AppendMessage(nil, vmc.AddressAvailable, []byte("iii"),
+		osc.AppendInt(osc.AppendInt(osc.AppendInt(nil, 1), 9), 0)))
+	raw("error_bundle", osc.AppendBundle(nil, osc.TimeTagImmediate))
+	raw("error_empty", []byte{})
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i].name < samples[j].name })
+
+	return samples
+}
@@ -0,0 +1,1835 @@
+// Code generated by vmcgen from marionette.json; DO NOT EDIT.
+
+package vmc
+
+import (
+	"fmt"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+)
+
+// OSC message addresses for the VMC marionette messages.
+const (
+	AddressAvailable               = "/VMC/Ext/OK"
+	AddressRelativeTime            = "/VMC/Ext/T"
+	AddressRootTransform           = "/VMC/Ext/Root/Pos"
+	AddressBoneTransform           = "/VMC/Ext/Bone/Pos"
+	AddressBlendShapeProxyValue    = "/VMC/Ext/Blend/Val"
+	AddressBlendShapeProxyApply    = "/VMC/Ext/Blend/Apply"
+	AddressCameraTransform         = "/VMC/Ext/Cam"
+	AddressControllerInput         = "/VMC/Ext/Con"
+	AddressKeyboardInput           = "/VMC/Ext/Key"
+	AddressMidiNoteInput           = "/VMC/Ext/Midi/Note"
+	AddressMidiCCValueInput        = "/VMC/Ext/Midi/CC/Val"
+	AddressMidiCCButtonInput       = "/VMC/Ext/Midi/CC/Bit"
+	AddressDeviceTransformHmd      = "/VMC/Ext/Hmd/Pos"
+	AddressDeviceTransformCon      = "/VMC/Ext/Con/Pos"
+	AddressDeviceTransformTra      = "/VMC/Ext/Tra/Pos"
+	AddressDeviceTransformHmdLocal = "/VMC/Ext/Hmd/Pos/Local"
+	AddressDeviceTransformConLocal = "/VMC/Ext/Con/Pos/Local"
+	AddressDeviceTransformTraLocal = "/VMC/Ext/Tra/Pos/Local"
+	AddressReceiveEnable           = "/VMC/Ext/Rcv"
+	AddressDirectionalLight        = "/VMC/Ext/Light"
+	AddressLocalVrm                = "/VMC/Ext/VRM"
+	AddressRemoteVrm               = "/VMC/Ext/Remote"
+	AddressOptionString            = "/VMC/Ext/Opt"
+	AddressBackgroundColor         = "/VMC/Ext/Setting/Color"
+	AddressWindowAttribute         = "/VMC/Ext/Setting/Win"
+	AddressLoadedSettingPath       = "/VMC/Ext/Config"
+	AddressEyeTarget               = "/VMC/Ext/Set/Eye"
+)
+
+// CalibrationState is the progress of the avatar calibration.
+type CalibrationState uint8
+
+// Possible values for the calibration state.
+const (
+	CalibrationStateUncalibrated CalibrationState = iota
+	CalibrationStateWaitingForCalibration
+	CalibrationStateCalibrating
+	CalibrationStateCalibrated
+)
+
+func (s CalibrationState) isValid() bool {
+	return s <= CalibrationStateCalibrated
+}
+
+func (s CalibrationState) String() string {
+	switch s {
+	case CalibrationStateUncalibrated:
+		return "Uncalibrated"
+	case CalibrationStateWaitingForCalibration:
+		return "WaitingForCalibration"
+	case CalibrationStateCalibrating:
+		return "Calibrating"
+	case CalibrationStateCalibrated:
+		return "Calibrated"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(s))
+	}
+}
+
+// CalibrationMode is the mode, that the avatar was calibrated with.
+type CalibrationMode uint8
+
+// Possible values for the calibration mode.
+const (
+	CalibrationModeNormal CalibrationMode = iota
+	CalibrationModeMrNormal
+	CalibrationModeMrFloorFix
+)
+
+func (m CalibrationMode) isValid() bool {
+	return m <= CalibrationModeMrFloorFix
+}
+
+func (m CalibrationMode) String() string {
+	switch m {
+	case CalibrationModeNormal:
+		return "Normal"
+	case CalibrationModeMrNormal:
+		return "MrNormal"
+	case CalibrationModeMrFloorFix:
+		return "MrFloorFix"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(m))
+	}
+}
+
+// ControllerActive is the kind of interaction with a controller button.
+type ControllerActive uint8
+
+// Possible values for the controller active state.
+const (
+	ControllerActiveRelease ControllerActive = iota
+	ControllerActivePress
+	ControllerActiveChangeAxis
+)
+
+func (a ControllerActive) isValid() bool {
+	return a <= ControllerActiveChangeAxis
+}
+
+func (a ControllerActive) String() string {
+	switch a {
+	case ControllerActiveRelease:
+		return "Release"
+	case ControllerActivePress:
+		return "Press"
+	case ControllerActiveChangeAxis:
+		return "ChangeAxis"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(a))
+	}
+}
+
+// DeviceType is the kind of device, that a DeviceTransform describes.
+type DeviceType uint8
+
+// Possible values for the device type.
+const (
+	DeviceTypeHmd DeviceType = iota
+	DeviceTypeController
+	DeviceTypeTracker
+)
+
+func (t DeviceType) String() string {
+	switch t {
+	case DeviceTypeHmd:
+		return "Hmd"
+	case DeviceTypeController:
+		return "Controller"
+	case DeviceTypeTracker:
+		return "Tracker"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(t))
+	}
+}
+
+// Available tells whether the avatar is loaded, and the state of its calibration and tracking.
+type Available struct {
+	Loaded           bool // Loaded tells whether an avatar is loaded.
+	CalibrationState Optional[CalibrationState]
+	CalibrationMode  Optional[CalibrationMode]
+	TrackingStatus   Optional[bool]  // TrackingStatus tells whether tracking works fine.
+	Version          ProtocolVersion // Version is the protocol version of the received variant.
+}
+
+func (a *Available) isMessage() {}
+
+// ProtocolVersion tells the version of the received message variant.
+func (a *Available) ProtocolVersion() ProtocolVersion {
+	return a.Version
+}
+
+func (a *Available) String() string {
+	return fmt.Sprintf(
+		"Available { Loaded: %v, CalibrationState: %v, CalibrationMode: %v, TrackingStatus: %v, Version: %v }",
+		a.Loaded,
+		a.CalibrationState,
+		a.CalibrationMode,
+		a.TrackingStatus,
+		a.Version,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer. The variant is selected
+// by the Version field. If the version is unknown, the latest variant, that has all its optional
+// fields present, is used.
+func (a *Available) AppendMessage(buf []byte) []byte {
+	version := a.Version
+	if version == ProtocolVersionUnknown {
+		version = ProtocolV1
+
+		if a.CalibrationState.Valid && a.CalibrationMode.Valid {
+			version = ProtocolV2_5
+
+			if a.TrackingStatus.Valid {
+				version = ProtocolV2_7
+			}
+		}
+	}
+
+	buf = osc.AppendString(buf, AddressAvailable)
+
+	switch {
+	case version >= ProtocolV2_7:
+		buf = osc.AppendString(buf, ",iiii")
+	case version >= ProtocolV2_5:
+		buf = osc.AppendString(buf, ",iii")
+	default:
+		buf = osc.AppendString(buf, ",i")
+	}
+
+	buf = appendBool(buf, a.Loaded)
+
+	if version >= ProtocolV2_5 {
+		buf = osc.AppendInt(buf, int32(a.CalibrationState.Value))
+		buf = osc.AppendInt(buf, int32(a.CalibrationMode.Value))
+	}
+
+	if version >= ProtocolV2_7 {
+		buf = appendBool(buf, a.TrackingStatus.Value)
+	}
+
+	return buf
+}
+
+func parseAvailable(tags, data []byte, msg *Available) error {
+	const (
+		typeTagsV1   = "i"
+		typeTagsV2_5 = "iii"
+		typeTagsV2_7 = "iiii"
+	)
+
+	var version ProtocolVersion
+
+	switch string(tags) {
+	case typeTagsV1:
+		version = ProtocolV1
+	case typeTagsV2_5:
+		version = ProtocolV2_5
+	case typeTagsV2_7:
+		version = ProtocolV2_7
+	default:
+		return InvalidTypeTagsError{
+			Found:    tags,
+			Expected: []string{typeTagsV1, typeTagsV2_5, typeTagsV2_7},
+		}
+	}
+
+	if len(data) < 4 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 4}
+	}
+
+	loaded := getInt32(data[0:4]) == 1
+	data = data[4:]
+
+	*msg = Available{
+		Loaded:           loaded,
+		CalibrationState: None[CalibrationState](),
+		CalibrationMode:  None[CalibrationMode](),
+		TrackingStatus:   None[bool](),
+		Version:          version,
+	}
+
+	if version >= ProtocolV2_5 {
+		if len(data) < 8 {
+			return InvalidBufferLengthError{Length: len(data), Expected: 8}
+		}
+
+		rawCalibrationState := getInt32(data[0:4])
+		calibrationState := CalibrationState(rawCalibrationState)
+		if !calibrationState.isValid() {
+			return InvalidEnumValueError{
+				Name:  "calibration state",
+				Value: rawCalibrationState,
+			}
+		}
+
+		rawCalibrationMode := getInt32(data[4:8])
+		calibrationMode := CalibrationMode(rawCalibrationMode)
+		if !calibrationMode.isValid() {
+			return InvalidEnumValueError{
+				Name:  "calibration mode",
+				Value: rawCalibrationMode,
+			}
+		}
+
+		data = data[8:]
+
+		msg.CalibrationState = Some(calibrationState)
+		msg.CalibrationMode = Some(calibrationMode)
+	}
+
+	if version >= ProtocolV2_7 {
+		if len(data) < 4 {
+			return InvalidBufferLengthError{Length: len(data), Expected: 4}
+		}
+
+		trackingStatus := getInt32(data[0:4]) == 1
+
+		msg.TrackingStatus = Some(trackingStatus)
+	}
+
+	return nil
+}
+
+// RelativeTime is the time in seconds, since the sender started.
+type RelativeTime struct {
+	Time float32
+}
+
+func (r *RelativeTime) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (r *RelativeTime) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_0
+}
+
+func (r *RelativeTime) String() string {
+	return fmt.Sprintf(
+		"RelativeTime { Time: %v }",
+		r.Time,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (r *RelativeTime) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressRelativeTime)
+	buf = osc.AppendString(buf, ",f")
+	buf = osc.AppendFloat(buf, r.Time)
+
+	return buf
+}
+
+func parseRelativeTime(tags, data []byte, msg *RelativeTime) error {
+	if string(tags) != "f" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"f"}}
+	}
+
+	if len(data) < 4 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 4}
+	}
+
+	time := getFloat32(data[0:4])
+
+	*msg = RelativeTime{
+		Time: time,
+	}
+
+	return nil
+}
+
+// RootTransform is the position and rotation of the avatar root.
+type RootTransform struct {
+	Name       []byte
+	Position   Vec3
+	Quaternion Vec4
+	Scale      Optional[Vec3]  // Scale is the scale of the avatar, used for MR setups.
+	Offset     Optional[Vec3]  // Offset is the position offset, used for MR setups.
+	Version    ProtocolVersion // Version is the protocol version of the received variant.
+}
+
+func (r *RootTransform) isMessage() {}
+
+// ProtocolVersion tells the version of the received message variant.
+func (r *RootTransform) ProtocolVersion() ProtocolVersion {
+	return r.Version
+}
+
+func (r *RootTransform) String() string {
+	return fmt.Sprintf(
+		"RootTransform { Name: %q, Position: %v, Quaternion: %v, Scale: %v, Offset: %v, Version: %v }",
+		r.Name,
+		r.Position,
+		r.Quaternion,
+		r.Scale,
+		r.Offset,
+		r.Version,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer. The variant is selected
+// by the Version field. If the version is unknown, the latest variant, that has all its optional
+// fields present, is used.
+func (r *RootTransform) AppendMessage(buf []byte) []byte {
+	version := r.Version
+	if version == ProtocolVersionUnknown {
+		version = ProtocolV2_0
+
+		if r.Scale.Valid && r.Offset.Valid {
+			version = ProtocolV2_1
+		}
+	}
+
+	buf = osc.AppendString(buf, AddressRootTransform)
+
+	switch {
+	case version >= ProtocolV2_1:
+		buf = osc.AppendString(buf, ",sfffffffffffff")
+	default:
+		buf = osc.AppendString(buf, ",sfffffff")
+	}
+
+	buf = osc.AppendStringBytes(buf, r.Name)
+	buf = appendVec3(buf, r.Position)
+	buf = appendVec4(buf, r.Quaternion)
+
+	if version >= ProtocolV2_1 {
+		buf = appendVec3(buf, r.Scale.Value)
+		buf = appendVec3(buf, r.Offset.Value)
+	}
+
+	return buf
+}
+
+func parseRootTransform(tags, data []byte, msg *RootTransform) error {
+	const (
+		typeTagsV2_0 = "sfffffff"
+		typeTagsV2_1 = "sfffffffffffff"
+	)
+
+	var version ProtocolVersion
+
+	switch string(tags) {
+	case typeTagsV2_0:
+		version = ProtocolV2_0
+	case typeTagsV2_1:
+		version = ProtocolV2_1
+	default:
+		return InvalidTypeTagsError{
+			Found:    tags,
+			Expected: []string{typeTagsV2_0, typeTagsV2_1},
+		}
+	}
+
+	name, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	if len(data) < 28 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 28}
+	}
+
+	position := getVec3(data[0:12])
+	quaternion := getVec4(data[12:28])
+	data = data[28:]
+
+	*msg = RootTransform{
+		Name:       name,
+		Position:   position,
+		Quaternion: quaternion,
+		Scale:      None[Vec3](),
+		Offset:     None[Vec3](),
+		Version:    version,
+	}
+
+	if version >= ProtocolV2_1 {
+		if len(data) < 24 {
+			return InvalidBufferLengthError{Length: len(data), Expected: 24}
+		}
+
+		scale := getVec3(data[0:12])
+		offset := getVec3(data[12:24])
+
+		msg.Scale = Some(scale)
+		msg.Offset = Some(offset)
+	}
+
+	return nil
+}
+
+// BoneTransform is the local position and rotation of a single humanoid bone.
+type BoneTransform struct {
+	Name       []byte // Name is the bone name, as defined by Unity's HumanBodyBones.
+	Position   Vec3
+	Quaternion Vec4
+}
+
+func (b *BoneTransform) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (b *BoneTransform) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_0
+}
+
+func (b *BoneTransform) String() string {
+	return fmt.Sprintf(
+		"BoneTransform { Name: %q, Position: %v, Quaternion: %v }",
+		b.Name,
+		b.Position,
+		b.Quaternion,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (b *BoneTransform) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressBoneTransform)
+	buf = osc.AppendString(buf, ",sfffffff")
+	buf = osc.AppendStringBytes(buf, b.Name)
+	buf = appendVec3(buf, b.Position)
+	buf = appendVec4(buf, b.Quaternion)
+
+	return buf
+}
+
+func parseBoneTransform(tags, data []byte, msg *BoneTransform) error {
+	if string(tags) != "sfffffff" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"sfffffff"}}
+	}
+
+	name, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	if len(data) < 28 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 28}
+	}
+
+	position := getVec3(data[0:12])
+	quaternion := getVec4(data[12:28])
+
+	*msg = BoneTransform{
+		Name:       name,
+		Position:   position,
+		Quaternion: quaternion,
+	}
+
+	return nil
+}
+
+// BlendShapeProxyValue is the value of a single blend shape. Values are collected until the next
+// BlendShapeProxyApply message.
+type BlendShapeProxyValue struct {
+	Name  []byte
+	Value float32
+}
+
+func (b *BlendShapeProxyValue) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (b *BlendShapeProxyValue) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_0
+}
+
+func (b *BlendShapeProxyValue) String() string {
+	return fmt.Sprintf(
+		"BlendShapeProxyValue { Name: %q, Value: %v }",
+		b.Name,
+		b.Value,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (b *BlendShapeProxyValue) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressBlendShapeProxyValue)
+	buf = osc.AppendString(buf, ",sf")
+	buf = osc.AppendStringBytes(buf, b.Name)
+	buf = osc.AppendFloat(buf, b.Value)
+
+	return buf
+}
+
+func parseBlendShapeProxyValue(tags, data []byte, msg *BlendShapeProxyValue) error {
+	if string(tags) != "sf" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"sf"}}
+	}
+
+	name, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	if len(data) < 4 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 4}
+	}
+
+	value := getFloat32(data[0:4])
+
+	*msg = BlendShapeProxyValue{
+		Name:  name,
+		Value: value,
+	}
+
+	return nil
+}
+
+// BlendShapeProxyApply applies all previously received blend shape values at once.
+type BlendShapeProxyApply struct{}
+
+func (b *BlendShapeProxyApply) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (b *BlendShapeProxyApply) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_0
+}
+
+func (b *BlendShapeProxyApply) String() string {
+	return "BlendShapeProxyApply {}"
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (b *BlendShapeProxyApply) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressBlendShapeProxyApply)
+	buf = osc.AppendString(buf, ",")
+
+	return buf
+}
+
+func parseBlendShapeProxyApply(tags, data []byte, msg *BlendShapeProxyApply) error {
+	if string(tags) != "" {
+		return InvalidTypeTagsError{Found: tags, Expected: nil}
+	}
+
+	*msg = BlendShapeProxyApply{}
+
+	return nil
+}
+
+// CameraTransform is the position, rotation and field of view of the camera.
+type CameraTransform struct {
+	Name       []byte
+	Position   Vec3
+	Quaternion Vec4
+	FOV        float32 // FOV is the field of view in degrees.
+}
+
+func (c *CameraTransform) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (c *CameraTransform) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_1
+}
+
+func (c *CameraTransform) String() string {
+	return fmt.Sprintf(
+		"CameraTransform { Name: %q, Position: %v, Quaternion: %v, FOV: %v }",
+		c.Name,
+		c.Position,
+		c.Quaternion,
+		c.FOV,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (c *CameraTransform) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressCameraTransform)
+	buf = osc.AppendString(buf, ",sffffffff")
+	buf = osc.AppendStringBytes(buf, c.Name)
+	buf = appendVec3(buf, c.Position)
+	buf = appendVec4(buf, c.Quaternion)
+	buf = osc.AppendFloat(buf, c.FOV)
+
+	return buf
+}
+
+func parseCameraTransform(tags, data []byte, msg *CameraTransform) error {
+	if string(tags) != "sffffffff" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"sffffffff"}}
+	}
+
+	name, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	if len(data) < 32 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 32}
+	}
+
+	position := getVec3(data[0:12])
+	quaternion := getVec4(data[12:28])
+	fov := getFloat32(data[28:32])
+
+	*msg = CameraTransform{
+		Name:       name,
+		Position:   position,
+		Quaternion: quaternion,
+		FOV:        fov,
+	}
+
+	return nil
+}
+
+// ControllerInput is a button or axis input of a VR controller.
+type ControllerInput struct {
+	Active  ControllerActive
+	Name    []byte
+	IsLeft  bool
+	IsTouch bool
+	IsAxis  bool
+	Axis    Vec3
+}
+
+func (c *ControllerInput) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (c *ControllerInput) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_1
+}
+
+func (c *ControllerInput) String() string {
+	return fmt.Sprintf(
+		"ControllerInput { Active: %v, Name: %q, IsLeft: %v, IsTouch: %v, IsAxis: %v, Axis: %v }",
+		c.Active,
+		c.Name,
+		c.IsLeft,
+		c.IsTouch,
+		c.IsAxis,
+		c.Axis,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (c *ControllerInput) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressControllerInput)
+	buf = osc.AppendString(buf, ",isiiifff")
+	buf = osc.AppendInt(buf, int32(c.Active))
+	buf = osc.AppendStringBytes(buf, c.Name)
+	buf = appendBool(buf, c.IsLeft)
+	buf = appendBool(buf, c.IsTouch)
+	buf = appendBool(buf, c.IsAxis)
+	buf = appendVec3(buf, c.Axis)
+
+	return buf
+}
+
+func parseControllerInput(tags, data []byte, msg *ControllerInput) error {
+	if string(tags) != "isiiifff" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"isiiifff"}}
+	}
+
+	if len(data) < 4 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 4}
+	}
+
+	rawActive := getInt32(data[0:4])
+	active := ControllerActive(rawActive)
+	if !active.isValid() {
+		return InvalidEnumValueError{
+			Name:  "active (controller)",
+			Value: rawActive,
+		}
+	}
+
+	data = data[4:]
+
+	name, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	if len(data) < 24 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 24}
+	}
+
+	isLeft := getInt32(data[0:4]) == 1
+	isTouch := getInt32(data[4:8]) == 1
+	isAxis := getInt32(data[8:12]) == 1
+	axis := getVec3(data[12:24])
+
+	*msg = ControllerInput{
+		Active:  active,
+		Name:    name,
+		IsLeft:  isLeft,
+		IsTouch: isTouch,
+		IsAxis:  isAxis,
+		Axis:    axis,
+	}
+
+	return nil
+}
+
+// KeyboardInput is a key press or release on the keyboard.
+type KeyboardInput struct {
+	Active  bool
+	Name    []byte
+	KeyCode int32
+}
+
+func (k *KeyboardInput) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (k *KeyboardInput) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_1
+}
+
+func (k *KeyboardInput) String() string {
+	return fmt.Sprintf(
+		"KeyboardInput { Active: %v, Name: %q, KeyCode: %v }",
+		k.Active,
+		k.Name,
+		k.KeyCode,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (k *KeyboardInput) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressKeyboardInput)
+	buf = osc.AppendString(buf, ",isi")
+	buf = appendBool(buf, k.Active)
+	buf = osc.AppendStringBytes(buf, k.Name)
+	buf = osc.AppendInt(buf, k.KeyCode)
+
+	return buf
+}
+
+func parseKeyboardInput(tags, data []byte, msg *KeyboardInput) error {
+	if string(tags) != "isi" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"isi"}}
+	}
+
+	if len(data) < 4 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 4}
+	}
+
+	active := getInt32(data[0:4]) == 1
+	data = data[4:]
+
+	name, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	if len(data) < 4 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 4}
+	}
+
+	keyCode := getInt32(data[0:4])
+
+	*msg = KeyboardInput{
+		Active:  active,
+		Name:    name,
+		KeyCode: keyCode,
+	}
+
+	return nil
+}
+
+// MidiNoteInput is a note played on a MIDI device.
+type MidiNoteInput struct {
+	Active   bool
+	Channel  int32
+	Note     int32
+	Velocity float32
+}
+
+func (m *MidiNoteInput) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (m *MidiNoteInput) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_2
+}
+
+func (m *MidiNoteInput) String() string {
+	return fmt.Sprintf(
+		"MidiNoteInput { Active: %v, Channel: %v, Note: %v, Velocity: %v }",
+		m.Active,
+		m.Channel,
+		m.Note,
+		m.Velocity,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (m *MidiNoteInput) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressMidiNoteInput)
+	buf = osc.AppendString(buf, ",iiif")
+	buf = appendBool(buf, m.Active)
+	buf = osc.AppendInt(buf, m.Channel)
+	buf = osc.AppendInt(buf, m.Note)
+	buf = osc.AppendFloat(buf, m.Velocity)
+
+	return buf
+}
+
+func parseMidiNoteInput(tags, data []byte, msg *MidiNoteInput) error {
+	if string(tags) != "iiif" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"iiif"}}
+	}
+
+	if len(data) < 16 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 16}
+	}
+
+	active := getInt32(data[0:4]) == 1
+	channel := getInt32(data[4:8])
+	note := getInt32(data[8:12])
+	velocity := getFloat32(data[12:16])
+
+	*msg = MidiNoteInput{
+		Active:   active,
+		Channel:  channel,
+		Note:     note,
+		Velocity: velocity,
+	}
+
+	return nil
+}
+
+// MidiCCValueInput is a value change of a MIDI control knob.
+type MidiCCValueInput struct {
+	Knob  int32
+	Value float32
+}
+
+func (m *MidiCCValueInput) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (m *MidiCCValueInput) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_2
+}
+
+func (m *MidiCCValueInput) String() string {
+	return fmt.Sprintf(
+		"MidiCCValueInput { Knob: %v, Value: %v }",
+		m.Knob,
+		m.Value,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (m *MidiCCValueInput) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressMidiCCValueInput)
+	buf = osc.AppendString(buf, ",if")
+	buf = osc.AppendInt(buf, m.Knob)
+	buf = osc.AppendFloat(buf, m.Value)
+
+	return buf
+}
+
+func parseMidiCCValueInput(tags, data []byte, msg *MidiCCValueInput) error {
+	if string(tags) != "if" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"if"}}
+	}
+
+	if len(data) < 8 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 8}
+	}
+
+	knob := getInt32(data[0:4])
+	value := getFloat32(data[4:8])
+
+	*msg = MidiCCValueInput{
+		Knob:  knob,
+		Value: value,
+	}
+
+	return nil
+}
+
+// MidiCCButtonInput is a state change of a MIDI control button.
+type MidiCCButtonInput struct {
+	Knob   int32
+	Active bool
+}
+
+func (m *MidiCCButtonInput) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (m *MidiCCButtonInput) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_2
+}
+
+func (m *MidiCCButtonInput) String() string {
+	return fmt.Sprintf(
+		"MidiCCButtonInput { Knob: %v, Active: %v }",
+		m.Knob,
+		m.Active,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (m *MidiCCButtonInput) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressMidiCCButtonInput)
+	buf = osc.AppendString(buf, ",ii")
+	buf = osc.AppendInt(buf, m.Knob)
+	buf = appendBool(buf, m.Active)
+
+	return buf
+}
+
+func parseMidiCCButtonInput(tags, data []byte, msg *MidiCCButtonInput) error {
+	if string(tags) != "ii" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"ii"}}
+	}
+
+	if len(data) < 8 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 8}
+	}
+
+	knob := getInt32(data[0:4])
+	active := getInt32(data[4:8]) == 1
+
+	*msg = MidiCCButtonInput{
+		Knob:   knob,
+		Active: active,
+	}
+
+	return nil
+}
+
+// DeviceTransform is the position and rotation of a tracked device. The device type and whether the
+// transform is local to the avatar are given by the message address.
+type DeviceTransform struct {
+	Device     DeviceType // Device is the kind of device.
+	Local      bool       // Local tells whether the transform is relative to the avatar, instead of the world.
+	Serial     []byte     // Serial is the serial number of the device.
+	Position   Vec3
+	Quaternion Vec4
+}
+
+func (d *DeviceTransform) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message address.
+func (d *DeviceTransform) ProtocolVersion() ProtocolVersion {
+	switch d.address() {
+	case AddressDeviceTransformHmdLocal, AddressDeviceTransformConLocal, AddressDeviceTransformTraLocal:
+		return ProtocolV2_3
+	default:
+		return ProtocolV2_2
+	}
+}
+
+func (d *DeviceTransform) String() string {
+	return fmt.Sprintf(
+		"DeviceTransform { Device: %v, Local: %v, Serial: %q, Position: %v, Quaternion: %v }",
+		d.Device,
+		d.Local,
+		d.Serial,
+		d.Position,
+		d.Quaternion,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (d *DeviceTransform) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, d.address())
+	buf = osc.AppendString(buf, ",sfffffff")
+	buf = osc.AppendStringBytes(buf, d.Serial)
+	buf = appendVec3(buf, d.Position)
+	buf = appendVec4(buf, d.Quaternion)
+
+	return buf
+}
+
+// address selects the message address, that matches the fields which aren't transmitted as
+// arguments.
+func (d *DeviceTransform) address() string {
+	switch {
+	case d.Device == DeviceTypeHmd && !d.Local:
+		return AddressDeviceTransformHmd
+	case d.Device == DeviceTypeController && !d.Local:
+		return AddressDeviceTransformCon
+	case d.Device == DeviceTypeTracker && !d.Local:
+		return AddressDeviceTransformTra
+	case d.Device == DeviceTypeHmd && d.Local:
+		return AddressDeviceTransformHmdLocal
+	case d.Device == DeviceTypeController && d.Local:
+		return AddressDeviceTransformConLocal
+	case d.Device == DeviceTypeTracker && d.Local:
+		return AddressDeviceTransformTraLocal
+	default:
+		return AddressDeviceTransformHmd
+	}
+}
+
+func parseDeviceTransform(
+	tags, data []byte,
+	device DeviceType,
+	local bool,
+	msg *DeviceTransform,
+) error {
+	if string(tags) != "sfffffff" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"sfffffff"}}
+	}
+
+	serial, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	if len(data) < 28 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 28}
+	}
+
+	position := getVec3(data[0:12])
+	quaternion := getVec4(data[12:28])
+
+	*msg = DeviceTransform{
+		Device:     device,
+		Local:      local,
+		Serial:     serial,
+		Position:   position,
+		Quaternion: quaternion,
+	}
+
+	return nil
+}
+
+// ReceiveEnable tells the receiver to enable or disable the reception of messages on a port.
+type ReceiveEnable struct {
+	Enable    bool
+	Port      int32
+	IPAddress Optional[[]byte]
+	Version   ProtocolVersion // Version is the protocol version of the received variant.
+}
+
+func (r *ReceiveEnable) isMessage() {}
+
+// ProtocolVersion tells the version of the received message variant.
+func (r *ReceiveEnable) ProtocolVersion() ProtocolVersion {
+	return r.Version
+}
+
+func (r *ReceiveEnable) String() string {
+	return fmt.Sprintf(
+		"ReceiveEnable { Enable: %v, Port: %v, IPAddress: %s, Version: %v }",
+		r.Enable,
+		r.Port,
+		quoteOptional(r.IPAddress),
+		r.Version,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer. The variant is selected
+// by the Version field. If the version is unknown, the latest variant, that has all its optional
+// fields present, is used.
+func (r *ReceiveEnable) AppendMessage(buf []byte) []byte {
+	version := r.Version
+	if version == ProtocolVersionUnknown {
+		version = ProtocolV2_4
+
+		if r.IPAddress.Valid {
+			version = ProtocolV2_7
+		}
+	}
+
+	buf = osc.AppendString(buf, AddressReceiveEnable)
+
+	switch {
+	case version >= ProtocolV2_7:
+		buf = osc.AppendString(buf, ",iis")
+	default:
+		buf = osc.AppendString(buf, ",ii")
+	}
+
+	buf = appendBool(buf, r.Enable)
+	buf = osc.AppendInt(buf, r.Port)
+
+	if version >= ProtocolV2_7 {
+		buf = osc.AppendStringBytes(buf, r.IPAddress.Value)
+	}
+
+	return buf
+}
+
+func parseReceiveEnable(tags, data []byte, msg *ReceiveEnable) error {
+	const (
+		typeTagsV2_4 = "ii"
+		typeTagsV2_7 = "iis"
+	)
+
+	var version ProtocolVersion
+
+	switch string(tags) {
+	case typeTagsV2_4:
+		version = ProtocolV2_4
+	case typeTagsV2_7:
+		version = ProtocolV2_7
+	default:
+		return InvalidTypeTagsError{
+			Found:    tags,
+			Expected: []string{typeTagsV2_4, typeTagsV2_7},
+		}
+	}
+
+	if len(data) < 8 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 8}
+	}
+
+	enable := getInt32(data[0:4]) == 1
+	port := getInt32(data[4:8])
+	data = data[8:]
+
+	*msg = ReceiveEnable{
+		Enable:    enable,
+		Port:      port,
+		IPAddress: None[[]byte](),
+		Version:   version,
+	}
+
+	if version >= ProtocolV2_7 {
+		ipAddress, _, err := getString(data)
+		if err != nil {
+			return err
+		}
+
+		msg.IPAddress = Some(ipAddress)
+	}
+
+	return nil
+}
+
+// DirectionalLight is the position, rotation and color of the directional light.
+type DirectionalLight struct {
+	Name       []byte
+	Position   Vec3
+	Quaternion Vec4
+	Color      Vec4 // Color is the light color as RGBA values.
+}
+
+func (d *DirectionalLight) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (d *DirectionalLight) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_4
+}
+
+func (d *DirectionalLight) String() string {
+	return fmt.Sprintf(
+		"DirectionalLight { Name: %q, Position: %v, Quaternion: %v, Color: %v }",
+		d.Name,
+		d.Position,
+		d.Quaternion,
+		d.Color,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (d *DirectionalLight) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressDirectionalLight)
+	buf = osc.AppendString(buf, ",sfffffffffff")
+	buf = osc.AppendStringBytes(buf, d.Name)
+	buf = appendVec3(buf, d.Position)
+	buf = appendVec4(buf, d.Quaternion)
+	buf = appendVec4(buf, d.Color)
+
+	return buf
+}
+
+func parseDirectionalLight(tags, data []byte, msg *DirectionalLight) error {
+	if string(tags) != "sfffffffffff" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"sfffffffffff"}}
+	}
+
+	name, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	if len(data) < 44 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 44}
+	}
+
+	position := getVec3(data[0:12])
+	quaternion := getVec4(data[12:28])
+	color := getVec4(data[28:44])
+
+	*msg = DirectionalLight{
+		Name:       name,
+		Position:   position,
+		Quaternion: quaternion,
+		Color:      color,
+	}
+
+	return nil
+}
+
+// LocalVrm describes the VRM model, that was loaded from a local file.
+type LocalVrm struct {
+	Path    []byte
+	Title   []byte
+	Hash    Optional[[]byte]
+	Version ProtocolVersion // Version is the protocol version of the received variant.
+}
+
+func (l *LocalVrm) isMessage() {}
+
+// ProtocolVersion tells the version of the received message variant.
+func (l *LocalVrm) ProtocolVersion() ProtocolVersion {
+	return l.Version
+}
+
+func (l *LocalVrm) String() string {
+	return fmt.Sprintf(
+		"LocalVrm { Path: %q, Title: %q, Hash: %s, Version: %v }",
+		l.Path,
+		l.Title,
+		quoteOptional(l.Hash),
+		l.Version,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer. The variant is selected
+// by the Version field. If the version is unknown, the latest variant, that has all its optional
+// fields present, is used.
+func (l *LocalVrm) AppendMessage(buf []byte) []byte {
+	version := l.Version
+	if version == ProtocolVersionUnknown {
+		version = ProtocolV2_4
+
+		if l.Hash.Valid {
+			version = ProtocolV2_7
+		}
+	}
+
+	buf = osc.AppendString(buf, AddressLocalVrm)
+
+	switch {
+	case version >= ProtocolV2_7:
+		buf = osc.AppendString(buf, ",sss")
+	default:
+		buf = osc.AppendString(buf, ",ss")
+	}
+
+	buf = osc.AppendStringBytes(buf, l.Path)
+	buf = osc.AppendStringBytes(buf, l.Title)
+
+	if version >= ProtocolV2_7 {
+		buf = osc.AppendStringBytes(buf, l.Hash.Value)
+	}
+
+	return buf
+}
+
+func parseLocalVrm(tags, data []byte, msg *LocalVrm) error {
+	const (
+		typeTagsV2_4 = "ss"
+		typeTagsV2_7 = "sss"
+	)
+
+	var version ProtocolVersion
+
+	switch string(tags) {
+	case typeTagsV2_4:
+		version = ProtocolV2_4
+	case typeTagsV2_7:
+		version = ProtocolV2_7
+	default:
+		return InvalidTypeTagsError{
+			Found:    tags,
+			Expected: []string{typeTagsV2_4, typeTagsV2_7},
+		}
+	}
+
+	path, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	title, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	*msg = LocalVrm{
+		Path:    path,
+		Title:   title,
+		Hash:    None[[]byte](),
+		Version: version,
+	}
+
+	if version >= ProtocolV2_7 {
+		hash, _, err := getString(data)
+		if err != nil {
+			return err
+		}
+
+		msg.Hash = Some(hash)
+	}
+
+	return nil
+}
+
+// RemoteVrm describes the VRM model, that was loaded from a remote service.
+type RemoteVrm struct {
+	Service []byte
+	JSON    []byte
+}
+
+func (r *RemoteVrm) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (r *RemoteVrm) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_4
+}
+
+func (r *RemoteVrm) String() string {
+	return fmt.Sprintf(
+		"RemoteVrm { Service: %q, JSON: %q }",
+		r.Service,
+		r.JSON,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (r *RemoteVrm) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressRemoteVrm)
+	buf = osc.AppendString(buf, ",ss")
+	buf = osc.AppendStringBytes(buf, r.Service)
+	buf = osc.AppendStringBytes(buf, r.JSON)
+
+	return buf
+}
+
+func parseRemoteVrm(tags, data []byte, msg *RemoteVrm) error {
+	if string(tags) != "ss" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"ss"}}
+	}
+
+	service, newData, err := getString(data)
+	if err != nil {
+		return err
+	}
+	data = newData
+
+	json, _, err := getString(data)
+	if err != nil {
+		return err
+	}
+
+	*msg = RemoteVrm{
+		Service: service,
+		JSON:    json,
+	}
+
+	return nil
+}
+
+// OptionString is a custom option, as configured in the sender.
+type OptionString struct {
+	Option []byte
+}
+
+func (o *OptionString) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (o *OptionString) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_5
+}
+
+func (o *OptionString) String() string {
+	return fmt.Sprintf(
+		"OptionString { Option: %q }",
+		o.Option,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (o *OptionString) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressOptionString)
+	buf = osc.AppendString(buf, ",s")
+	buf = osc.AppendStringBytes(buf, o.Option)
+
+	return buf
+}
+
+func parseOptionString(tags, data []byte, msg *OptionString) error {
+	if string(tags) != "s" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"s"}}
+	}
+
+	option, _, err := getString(data)
+	if err != nil {
+		return err
+	}
+
+	*msg = OptionString{
+		Option: option,
+	}
+
+	return nil
+}
+
+// BackgroundColor is the background color of the sender's window.
+type BackgroundColor struct {
+	Color Vec4 // Color is the background color as RGBA values.
+}
+
+func (b *BackgroundColor) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (b *BackgroundColor) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_5
+}
+
+func (b *BackgroundColor) String() string {
+	return fmt.Sprintf(
+		"BackgroundColor { Color: %v }",
+		b.Color,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (b *BackgroundColor) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressBackgroundColor)
+	buf = osc.AppendString(buf, ",ffff")
+	buf = appendVec4(buf, b.Color)
+
+	return buf
+}
+
+func parseBackgroundColor(tags, data []byte, msg *BackgroundColor) error {
+	if string(tags) != "ffff" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"ffff"}}
+	}
+
+	if len(data) < 16 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 16}
+	}
+
+	color := getVec4(data[0:16])
+
+	*msg = BackgroundColor{
+		Color: color,
+	}
+
+	return nil
+}
+
+// WindowAttribute describes the window settings of the sender.
+type WindowAttribute struct {
+	IsTopMost          bool
+	IsTransparent      bool
+	WindowClickThrough bool
+	HideBorder         bool
+}
+
+func (w *WindowAttribute) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (w *WindowAttribute) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_5
+}
+
+func (w *WindowAttribute) String() string {
+	return fmt.Sprintf(
+		"WindowAttribute { IsTopMost: %v, IsTransparent: %v, WindowClickThrough: %v, HideBorder: %v }",
+		w.IsTopMost,
+		w.IsTransparent,
+		w.WindowClickThrough,
+		w.HideBorder,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (w *WindowAttribute) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressWindowAttribute)
+	buf = osc.AppendString(buf, ",iiii")
+	buf = appendBool(buf, w.IsTopMost)
+	buf = appendBool(buf, w.IsTransparent)
+	buf = appendBool(buf, w.WindowClickThrough)
+	buf = appendBool(buf, w.HideBorder)
+
+	return buf
+}
+
+func parseWindowAttribute(tags, data []byte, msg *WindowAttribute) error {
+	if string(tags) != "iiii" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"iiii"}}
+	}
+
+	if len(data) < 16 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 16}
+	}
+
+	isTopMost := getInt32(data[0:4]) == 1
+	isTransparent := getInt32(data[4:8]) == 1
+	windowClickThrough := getInt32(data[8:12]) == 1
+	hideBorder := getInt32(data[12:16]) == 1
+
+	*msg = WindowAttribute{
+		IsTopMost:          isTopMost,
+		IsTransparent:      isTransparent,
+		WindowClickThrough: windowClickThrough,
+		HideBorder:         hideBorder,
+	}
+
+	return nil
+}
+
+// LoadedSettingPath is the file path of the loaded settings.
+type LoadedSettingPath struct {
+	Path []byte
+}
+
+func (l *LoadedSettingPath) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (l *LoadedSettingPath) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_5
+}
+
+func (l *LoadedSettingPath) String() string {
+	return fmt.Sprintf(
+		"LoadedSettingPath { Path: %q }",
+		l.Path,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (l *LoadedSettingPath) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressLoadedSettingPath)
+	buf = osc.AppendString(buf, ",s")
+	buf = osc.AppendStringBytes(buf, l.Path)
+
+	return buf
+}
+
+func parseLoadedSettingPath(tags, data []byte, msg *LoadedSettingPath) error {
+	if string(tags) != "s" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"s"}}
+	}
+
+	path, _, err := getString(data)
+	if err != nil {
+		return err
+	}
+
+	*msg = LoadedSettingPath{
+		Path: path,
+	}
+
+	return nil
+}
+
+// EyeTarget is the world position, that the avatar's eyes look at.
+type EyeTarget struct {
+	Enable   bool // Enable tells whether the eyes follow the target.
+	Position Vec3
+}
+
+func (e *EyeTarget) isMessage() {}
+
+// ProtocolVersion tells the version that introduced the message.
+func (e *EyeTarget) ProtocolVersion() ProtocolVersion {
+	return ProtocolV2_3
+}
+
+func (e *EyeTarget) String() string {
+	return fmt.Sprintf(
+		"EyeTarget { Enable: %v, Position: %v }",
+		e.Enable,
+		e.Position,
+	)
+}
+
+// AppendMessage appends the message in its OSC encoding to the buffer.
+func (e *EyeTarget) AppendMessage(buf []byte) []byte {
+	buf = osc.AppendString(buf, AddressEyeTarget)
+	buf = osc.AppendString(buf, ",ifff")
+	buf = appendBool(buf, e.Enable)
+	buf = appendVec3(buf, e.Position)
+
+	return buf
+}
+
+func parseEyeTarget(tags, data []byte, msg *EyeTarget) error {
+	if string(tags) != "ifff" {
+		return InvalidTypeTagsError{Found: tags, Expected: []string{"ifff"}}
+	}
+
+	if len(data) < 16 {
+		return InvalidBufferLengthError{Length: len(data), Expected: 16}
+	}
+
+	enable := getInt32(data[0:4]) == 1
+	position := getVec3(data[4:16])
+
+	*msg = EyeTarget{
+		Enable:   enable,
+		Position: position,
+	}
+
+	return nil
+}
+
+// messageStorage keeps a reusable value for each message type, so a Decoder only allocates each
+// of them once.
+type messageStorage struct {
+	available            *Available
+	relativeTime         *RelativeTime
+	rootTransform        *RootTransform
+	boneTransform        *BoneTransform
+	blendShapeProxyValue *BlendShapeProxyValue
+	blendShapeProxyApply *BlendShapeProxyApply
+	cameraTransform      *CameraTransform
+	controllerInput      *ControllerInput
+	keyboardInput        *KeyboardInput
+	midiNoteInput        *MidiNoteInput
+	midiCCValueInput     *MidiCCValueInput
+	midiCCButtonInput    *MidiCCButtonInput
+	deviceTransform      *DeviceTransform
+	receiveEnable        *ReceiveEnable
+	directionalLight     *DirectionalLight
+	localVrm             *LocalVrm
+	remoteVrm            *RemoteVrm
+	optionString         *OptionString
+	backgroundColor      *BackgroundColor
+	windowAttribute      *WindowAttribute
+	loadedSettingPath    *LoadedSettingPath
+	eyeTarget            *EyeTarget
+}
+
+// decode parses the arguments of the message with the given address into the storage. It returns
+// false, if the address doesn't belong to any of the known messages.
+func (s *messageStorage) decode(address, tags, data []byte) (Message, bool, error) {
+	switch string(address) {
+	case AddressAvailable:
+		msg, err := decodeInto(&s.available, tags, data, parseAvailable)
+
+		return msg, true, err
+	case AddressRelativeTime:
+		msg, err := decodeInto(&s.relativeTime, tags, data, parseRelativeTime)
+
+		return msg, true, err
+	case AddressRootTransform:
+		msg, err := decodeInto(&s.rootTransform, tags, data, parseRootTransform)
+
+		return msg, true, err
+	case AddressBoneTransform:
+		msg, err := decodeInto(&s.boneTransform, tags, data, parseBoneTransform)
+
+		return msg, true, err
+	case AddressBlendShapeProxyValue:
+		msg, err := decodeInto(&s.blendShapeProxyValue, tags, data, parseBlendShapeProxyValue)
+
+		return msg, true, err
+	case AddressBlendShapeProxyApply:
+		msg, err := decodeInto(&s.blendShapeProxyApply, tags, data, parseBlendShapeProxyApply)
+
+		return msg, true, err
+	case AddressCameraTransform:
+		msg, err := decodeInto(&s.cameraTransform, tags, data, parseCameraTransform)
+
+		return msg, true, err
+	case AddressControllerInput:
+		msg, err := decodeInto(&s.controllerInput, tags, data, parseControllerInput)
+
+		return msg, true, err
+	case AddressKeyboardInput:
+		msg, err := decodeInto(&s.keyboardInput, tags, data, parseKeyboardInput)
+
+		return msg, true, err
+	case AddressMidiNoteInput:
+		msg, err := decodeInto(&s.midiNoteInput, tags, data, parseMidiNoteInput)
+
+		return msg, true, err
+	case AddressMidiCCValueInput:
+		msg, err := decodeInto(&s.midiCCValueInput, tags, data, parseMidiCCValueInput)
+
+		return msg, true, err
+	case AddressMidiCCButtonInput:
+		msg, err := decodeInto(&s.midiCCButtonInput, tags, data, parseMidiCCButtonInput)
+
+		return msg, true, err
+	case AddressDeviceTransformHmd:
+		msg, err := decodeInto(&s.deviceTransform, tags, data, func(tags, data []byte, msg *DeviceTransform) error {
+			return parseDeviceTransform(tags, data, DeviceTypeHmd, false, msg)
+		})
+
+		return msg, true, err
+	case AddressDeviceTransformCon:
+		msg, err := decodeInto(&s.deviceTransform, tags, data, func(tags, data []byte, msg *DeviceTransform) error {
+			return parseDeviceTransform(tags, data, DeviceTypeController, false, msg)
+		})
+
+		return msg, true, err
+	case AddressDeviceTransformTra:
+		msg, err := decodeInto(&s.deviceTransform, tags, data, func(tags, data []byte, msg *DeviceTransform) error {
+			return parseDeviceTransform(tags, data, DeviceTypeTracker, false, msg)
+		})
+
+		return msg, true, err
+	case AddressDeviceTransformHmdLocal:
+		msg, err := decodeInto(&s.deviceTransform, tags, data, func(tags, data []byte, msg *DeviceTransform) error {
+			return parseDeviceTransform(tags, data, DeviceTypeHmd, true, msg)
+		})
+
+		return msg, true, err
+	case AddressDeviceTransformConLocal:
+		msg, err := decodeInto(&s.deviceTransform, tags, data, func(tags, data []byte, msg *DeviceTransform) error {
+			return parseDeviceTransform(tags, data, DeviceTypeController, true, msg)
+		})
+
+		return msg, true, err
+	case AddressDeviceTransformTraLocal:
+		msg, err := decodeInto(&s.deviceTransform, tags, data, func(tags, data []byte, msg *DeviceTransform) error {
+			return parseDeviceTransform(tags, data, DeviceTypeTracker, true, msg)
+		})
+
+		return msg, true, err
+	case AddressReceiveEnable:
+		msg, err := decodeInto(&s.receiveEnable, tags, data, parseReceiveEnable)
+
+		return msg, true, err
+	case AddressDirectionalLight:
+		msg, err := decodeInto(&s.directionalLight, tags, data, parseDirectionalLight)
+
+		return msg, true, err
+	case AddressLocalVrm:
+		msg, err := decodeInto(&s.localVrm, tags, data, parseLocalVrm)
+
+		return msg, true, err
+	case AddressRemoteVrm:
+		msg, err := decodeInto(&s.remoteVrm, tags, data, parseRemoteVrm)
+
+		return msg, true, err
+	case AddressOptionString:
+		msg, err := decodeInto(&s.optionString, tags, data, parseOptionString)
+
+		return msg, true, err
+	case AddressBackgroundColor:
+		msg, err := decodeInto(&s.backgroundColor, tags, data, parseBackgroundColor)
+
+		return msg, true, err
+	case AddressWindowAttribute:
+		msg, err := decodeInto(&s.windowAttribute, tags, data, parseWindowAttribute)
+
+		return msg, true, err
+	case AddressLoadedSettingPath:
+		msg, err := decodeInto(&s.loadedSettingPath, tags, data, parseLoadedSettingPath)
+
+		return msg, true, err
+	case AddressEyeTarget:
+		msg, err := decodeInto(&s.eyeTarget, tags, data, parseEyeTarget)
+
+		return msg, true, err
+	default:
+		return nil, false, nil
+	}
+}
@@ -0,0 +1,153 @@
+// Code generated by vmcgen from marionette.json; DO NOT EDIT.
+
+package vmc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessagesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  vmc.Encodable
+		want vmc.Message
+	}{
+		{name: "Available/V1", msg: &vmc.Available{Loaded: true, CalibrationState: vmc.None[vmc.CalibrationState](), CalibrationMode: vmc.None[vmc.CalibrationMode](), TrackingStatus: vmc.None[bool](), Version: vmc.ProtocolV1}},
+		{name: "Available/V2_5", msg: &vmc.Available{Loaded: true, CalibrationState: vmc.Some(vmc.CalibrationStateCalibrated), CalibrationMode: vmc.Some(vmc.CalibrationModeMrFloorFix), TrackingStatus: vmc.None[bool](), Version: vmc.ProtocolV2_5}},
+		{name: "Available/V2_7", msg: &vmc.Available{Loaded: true, CalibrationState: vmc.Some(vmc.CalibrationStateCalibrated), CalibrationMode: vmc.Some(vmc.CalibrationModeMrFloorFix), TrackingStatus: vmc.Some(true), Version: vmc.ProtocolV2_7}},
+		{name: "Available/inferred", msg: &vmc.Available{Loaded: true, CalibrationState: vmc.Some(vmc.CalibrationStateCalibrated), CalibrationMode: vmc.Some(vmc.CalibrationModeMrFloorFix), TrackingStatus: vmc.Some(true), Version: vmc.ProtocolVersionUnknown}, want: &vmc.Available{Loaded: true, CalibrationState: vmc.Some(vmc.CalibrationStateCalibrated), CalibrationMode: vmc.Some(vmc.CalibrationModeMrFloorFix), TrackingStatus: vmc.Some(true), Version: vmc.ProtocolV2_7}},
+		{name: "RelativeTime", msg: &vmc.RelativeTime{Time: 1.5}},
+		{name: "RootTransform/V2_0", msg: &vmc.RootTransform{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}, Scale: vmc.None[vmc.Vec3](), Offset: vmc.None[vmc.Vec3](), Version: vmc.ProtocolV2_0}},
+		{name: "RootTransform/V2_1", msg: &vmc.RootTransform{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}, Scale: vmc.Some(vmc.Vec3{X: 9.5, Y: 10.5, Z: 11.5}), Offset: vmc.Some(vmc.Vec3{X: 12.5, Y: 13.5, Z: 14.5}), Version: vmc.ProtocolV2_1}},
+		{name: "RootTransform/inferred", msg: &vmc.RootTransform{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}, Scale: vmc.Some(vmc.Vec3{X: 9.5, Y: 10.5, Z: 11.5}), Offset: vmc.Some(vmc.Vec3{X: 12.5, Y: 13.5, Z: 14.5}), Version: vmc.ProtocolVersionUnknown}, want: &vmc.RootTransform{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}, Scale: vmc.Some(vmc.Vec3{X: 9.5, Y: 10.5, Z: 11.5}), Offset: vmc.Some(vmc.Vec3{X: 12.5, Y: 13.5, Z: 14.5}), Version: vmc.ProtocolV2_1}},
+		{name: "BoneTransform", msg: &vmc.BoneTransform{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}}},
+		{name: "BlendShapeProxyValue", msg: &vmc.BlendShapeProxyValue{Name: []byte("name"), Value: 2.5}},
+		{name: "BlendShapeProxyApply", msg: &vmc.BlendShapeProxyApply{}},
+		{name: "CameraTransform", msg: &vmc.CameraTransform{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}, FOV: 9.5}},
+		{name: "ControllerInput", msg: &vmc.ControllerInput{Active: vmc.ControllerActiveChangeAxis, Name: []byte("name"), IsLeft: true, IsTouch: true, IsAxis: true, Axis: vmc.Vec3{X: 6.5, Y: 7.5, Z: 8.5}}},
+		{name: "KeyboardInput", msg: &vmc.KeyboardInput{Active: true, Name: []byte("name"), KeyCode: 3}},
+		{name: "MidiNoteInput", msg: &vmc.MidiNoteInput{Active: true, Channel: 2, Note: 3, Velocity: 4.5}},
+		{name: "MidiCCValueInput", msg: &vmc.MidiCCValueInput{Knob: 1, Value: 2.5}},
+		{name: "MidiCCButtonInput", msg: &vmc.MidiCCButtonInput{Knob: 1, Active: true}},
+		{name: "DeviceTransform/AddressDeviceTransformHmd", msg: &vmc.DeviceTransform{Device: vmc.DeviceTypeHmd, Local: false, Serial: []byte("serial"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}}},
+		{name: "DeviceTransform/AddressDeviceTransformCon", msg: &vmc.DeviceTransform{Device: vmc.DeviceTypeController, Local: false, Serial: []byte("serial"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}}},
+		{name: "DeviceTransform/AddressDeviceTransformTra", msg: &vmc.DeviceTransform{Device: vmc.DeviceTypeTracker, Local: false, Serial: []byte("serial"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}}},
+		{name: "DeviceTransform/AddressDeviceTransformHmdLocal", msg: &vmc.DeviceTransform{Device: vmc.DeviceTypeHmd, Local: true, Serial: []byte("serial"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}}},
+		{name: "DeviceTransform/AddressDeviceTransformConLocal", msg: &vmc.DeviceTransform{Device: vmc.DeviceTypeController, Local: true, Serial: []byte("serial"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}}},
+		{name: "DeviceTransform/AddressDeviceTransformTraLocal", msg: &vmc.DeviceTransform{Device: vmc.DeviceTypeTracker, Local: true, Serial: []byte("serial"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}}},
+		{name: "ReceiveEnable/V2_4", msg: &vmc.ReceiveEnable{Enable: true, Port: 2, IPAddress: vmc.None[[]byte](), Version: vmc.ProtocolV2_4}},
+		{name: "ReceiveEnable/V2_7", msg: &vmc.ReceiveEnable{Enable: true, Port: 2, IPAddress: vmc.Some([]byte("ipaddress")), Version: vmc.ProtocolV2_7}},
+		{name: "ReceiveEnable/inferred", msg: &vmc.ReceiveEnable{Enable: true, Port: 2, IPAddress: vmc.Some([]byte("ipaddress")), Version: vmc.ProtocolVersionUnknown}, want: &vmc.ReceiveEnable{Enable: true, Port: 2, IPAddress: vmc.Some([]byte("ipaddress")), Version: vmc.ProtocolV2_7}},
+		{name: "DirectionalLight", msg: &vmc.DirectionalLight{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}, Color: vmc.Vec4{X: 9.5, Y: 10.5, Z: 11.5, W: 12.5}}},
+		{name: "LocalVrm/V2_4", msg: &vmc.LocalVrm{Path: []byte("path"), Title: []byte("title"), Hash: vmc.None[[]byte](), Version: vmc.ProtocolV2_4}},
+		{name: "LocalVrm/V2_7", msg: &vmc.LocalVrm{Path: []byte("path"), Title: []byte("title"), Hash: vmc.Some([]byte("hash")), Version: vmc.ProtocolV2_7}},
+		{name: "LocalVrm/inferred", msg: &vmc.LocalVrm{Path: []byte("path"), Title: []byte("title"), Hash: vmc.Some([]byte("hash")), Version: vmc.ProtocolVersionUnknown}, want: &vmc.LocalVrm{Path: []byte("path"), Title: []byte("title"), Hash: vmc.Some([]byte("hash")), Version: vmc.ProtocolV2_7}},
+		{name: "RemoteVrm", msg: &vmc.RemoteVrm{Service: []byte("service"), JSON: []byte("json")}},
+		{name: "OptionString", msg: &vmc.OptionString{Option: []byte("option")}},
+		{name: "BackgroundColor", msg: &vmc.BackgroundColor{Color: vmc.Vec4{X: 1.5, Y: 2.5, Z: 3.5, W: 4.5}}},
+		{name: "WindowAttribute", msg: &vmc.WindowAttribute{IsTopMost: true, IsTransparent: true, WindowClickThrough: true, HideBorder: true}},
+		{name: "LoadedSettingPath", msg: &vmc.LoadedSettingPath{Path: []byte("path")}},
+		{name: "EyeTarget", msg: &vmc.EyeTarget{Enable: true, Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want == nil {
+				want = tt.msg
+			}
+
+			got, err := vmc.ParseMessage(tt.msg.AppendMessage(nil))
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestMessagesInvalidTypeTags(t *testing.T) {
+	tests := []struct {
+		address  string
+		expected []string
+	}{
+		{vmc.AddressAvailable, []string{"i", "iii", "iiii"}},
+		{vmc.AddressRelativeTime, []string{"f"}},
+		{vmc.AddressRootTransform, []string{"sfffffff", "sfffffffffffff"}},
+		{vmc.AddressBoneTransform, []string{"sfffffff"}},
+		{vmc.AddressBlendShapeProxyValue, []string{"sf"}},
+		{vmc.AddressBlendShapeProxyApply, nil},
+		{vmc.AddressCameraTransform, []string{"sffffffff"}},
+		{vmc.AddressControllerInput, []string{"isiiifff"}},
+		{vmc.AddressKeyboardInput, []string{"isi"}},
+		{vmc.AddressMidiNoteInput, []string{"iiif"}},
+		{vmc.AddressMidiCCValueInput, []string{"if"}},
+		{vmc.AddressMidiCCButtonInput, []string{"ii"}},
+		{vmc.AddressDeviceTransformHmd, []string{"sfffffff"}},
+		{vmc.AddressDeviceTransformCon, []string{"sfffffff"}},
+		{vmc.AddressDeviceTransformTra, []string{"sfffffff"}},
+		{vmc.AddressDeviceTransformHmdLocal, []string{"sfffffff"}},
+		{vmc.AddressDeviceTransformConLocal, []string{"sfffffff"}},
+		{vmc.AddressDeviceTransformTraLocal, []string{"sfffffff"}},
+		{vmc.AddressReceiveEnable, []string{"ii", "iis"}},
+		{vmc.AddressDirectionalLight, []string{"sfffffffffff"}},
+		{vmc.AddressLocalVrm, []string{"ss", "sss"}},
+		{vmc.AddressRemoteVrm, []string{"ss"}},
+		{vmc.AddressOptionString, []string{"s"}},
+		{vmc.AddressBackgroundColor, []string{"ffff"}},
+		{vmc.AddressWindowAttribute, []string{"iiii"}},
+		{vmc.AddressLoadedSettingPath, []string{"s"}},
+		{vmc.AddressEyeTarget, []string{"ifff"}},
+	}
+
+	for _, tt := range tests {
+		_, err := vmc.ParseMessage(osc.AppendMessage(nil, tt.address, []byte("N"), nil))
+
+		var tagsErr vmc.InvalidTypeTagsError
+		require.ErrorAs(t, err, &tagsErr, tt.address)
+		assert.Equal(t, tt.expected, tagsErr.Expected, tt.address)
+	}
+}
+
+func TestMessagesTruncated(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  vmc.Encodable
+	}{
+		{name: "Available", msg: &vmc.Available{Loaded: true, CalibrationState: vmc.Some(vmc.CalibrationStateCalibrated), CalibrationMode: vmc.Some(vmc.CalibrationModeMrFloorFix), TrackingStatus: vmc.Some(true), Version: vmc.ProtocolV2_7}},
+		{name: "RelativeTime", msg: &vmc.RelativeTime{Time: 1.5}},
+		{name: "RootTransform", msg: &vmc.RootTransform{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}, Scale: vmc.Some(vmc.Vec3{X: 9.5, Y: 10.5, Z: 11.5}), Offset: vmc.Some(vmc.Vec3{X: 12.5, Y: 13.5, Z: 14.5}), Version: vmc.ProtocolV2_1}},
+		{name: "BoneTransform", msg: &vmc.BoneTransform{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}}},
+		{name: "BlendShapeProxyValue", msg: &vmc.BlendShapeProxyValue{Name: []byte("name"), Value: 2.5}},
+		{name: "CameraTransform", msg: &vmc.CameraTransform{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}, FOV: 9.5}},
+		{name: "ControllerInput", msg: &vmc.ControllerInput{Active: vmc.ControllerActiveChangeAxis, Name: []byte("name"), IsLeft: true, IsTouch: true, IsAxis: true, Axis: vmc.Vec3{X: 6.5, Y: 7.5, Z: 8.5}}},
+		{name: "KeyboardInput", msg: &vmc.KeyboardInput{Active: true, Name: []byte("name"), KeyCode: 3}},
+		{name: "MidiNoteInput", msg: &vmc.MidiNoteInput{Active: true, Channel: 2, Note: 3, Velocity: 4.5}},
+		{name: "MidiCCValueInput", msg: &vmc.MidiCCValueInput{Knob: 1, Value: 2.5}},
+		{name: "MidiCCButtonInput", msg: &vmc.MidiCCButtonInput{Knob: 1, Active: true}},
+		{name: "DeviceTransform", msg: &vmc.DeviceTransform{Device: vmc.DeviceTypeHmd, Local: false, Serial: []byte("serial"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}}},
+		{name: "ReceiveEnable", msg: &vmc.ReceiveEnable{Enable: true, Port: 2, IPAddress: vmc.Some([]byte("ipaddress")), Version: vmc.ProtocolV2_7}},
+		{name: "DirectionalLight", msg: &vmc.DirectionalLight{Name: []byte("name"), Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}, Quaternion: vmc.Vec4{X: 5.5, Y: 6.5, Z: 7.5, W: 8.5}, Color: vmc.Vec4{X: 9.5, Y: 10.5, Z: 11.5, W: 12.5}}},
+		{name: "LocalVrm", msg: &vmc.LocalVrm{Path: []byte("path"), Title: []byte("title"), Hash: vmc.Some([]byte("hash")), Version: vmc.ProtocolV2_7}},
+		{name: "RemoteVrm", msg: &vmc.RemoteVrm{Service: []byte("service"), JSON: []byte("json")}},
+		{name: "OptionString", msg: &vmc.OptionString{Option: []byte("option")}},
+		{name: "BackgroundColor", msg: &vmc.BackgroundColor{Color: vmc.Vec4{X: 1.5, Y: 2.5, Z: 3.5, W: 4.5}}},
+		{name: "WindowAttribute", msg: &vmc.WindowAttribute{IsTopMost: true, IsTransparent: true, WindowClickThrough: true, HideBorder: true}},
+		{name: "LoadedSettingPath", msg: &vmc.LoadedSettingPath{Path: []byte("path")}},
+		{name: "EyeTarget", msg: &vmc.EyeTarget{Enable: true, Position: vmc.Vec3{X: 2.5, Y: 3.5, Z: 4.5}}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			raw := tt.msg.AppendMessage(nil)
+
+			_, err := vmc.ParseMessage(raw[:len(raw)-4])
+			assert.Error(t, err)
+		})
+	}
+}
@@ -21,36 +21,34 @@ func TestParseAvailable(t *testing.T) {
 		[]byte("/VMC/Ext/OK\x00,i\x00\x00\x00\x00\x00\x01"),
 		&vmc.Available{
 			Loaded:           true,
-			CalibrationState: nil,
-			CalibrationMode:  nil,
-			TrackingStatus:   nil,
+			CalibrationState: vmc.None[vmc.CalibrationState](),
+			CalibrationMode:  vmc.None[vmc.CalibrationMode](),
+			TrackingStatus:   vmc.None[bool](),
+			Version:          vmc.ProtocolV1,
 		},
 	)
 
-	calibrated := vmc.CalibrationStateCalibrated
-	mrNormal := vmc.CalibrationModeMrNormal
-
 	assertMessage(
 		t,
 		[]byte("/VMC/Ext/OK\x00,iii\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x03\x00\x00\x00\x01"),
 		&vmc.Available{
 			Loaded:           true,
-			CalibrationState: &calibrated,
-			CalibrationMode:  &mrNormal,
-			TrackingStatus:   nil,
+			CalibrationState: vmc.Some(vmc.CalibrationStateCalibrated),
+			CalibrationMode:  vmc.Some(vmc.CalibrationModeMrNormal),
+			TrackingStatus:   vmc.None[bool](),
+			Version:          vmc.ProtocolV2_5,
 		},
 	)
 
-	trackingStatus := true
-
 	assertMessage(
 		t,
 		[]byte("/VMC/Ext/OK\x00,iiii\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x03\x00\x00\x00\x01\x00\x00\x00\x01"),
 		&vmc.Available{
 			Loaded:           true,
-			CalibrationState: &calibrated,
-			CalibrationMode:  &mrNormal,
-			TrackingStatus:   &trackingStatus,
+			CalibrationState: vmc.Some(vmc.CalibrationStateCalibrated),
+			CalibrationMode:  vmc.Some(vmc.CalibrationModeMrNormal),
+			TrackingStatus:   vmc.Some(true),
+			Version:          vmc.ProtocolV2_7,
 		},
 	)
 }
@@ -73,8 +71,9 @@ func TestParseRootTransform(t *testing.T) {
 			Name:       []byte("tst"),
 			Position:   vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3},
 			Quaternion: vmc.Vec4{X: 2.1, Y: 2.2, Z: 2.3, W: 2.4},
-			Scale:      nil,
-			Offset:     nil,
+			Scale:      vmc.None[vmc.Vec3](),
+			Offset:     vmc.None[vmc.Vec3](),
+			Version:    vmc.ProtocolV2_0,
 		},
 	)
 	assertMessage(
@@ -84,8 +83,9 @@ func TestParseRootTransform(t *testing.T) {
 			Name:       []byte("tst"),
 			Position:   vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3},
 			Quaternion: vmc.Vec4{X: 2.1, Y: 2.2, Z: 2.3, W: 2.4},
-			Scale:      &vmc.Vec3{X: 3.1, Y: 3.2, Z: 3.3},
-			Offset:     &vmc.Vec3{X: 4.1, Y: 4.2, Z: 4.3},
+			Scale:      vmc.Some(vmc.Vec3{X: 3.1, Y: 3.2, Z: 3.3}),
+			Offset:     vmc.Some(vmc.Vec3{X: 4.1, Y: 4.2, Z: 4.3}),
+			Version:    vmc.ProtocolV2_1,
 		},
 	)
 }
@@ -201,6 +201,8 @@ func TestParseDeviceTransform(t *testing.T) {
 		t,
 		[]byte("/VMC/Ext/Hmd/Pos\x00\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a"),
 		&vmc.DeviceTransform{
+			Device:     vmc.DeviceTypeHmd,
+			Local:      false,
 			Serial:     []byte("tst"),
 			Position:   vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3},
 			Quaternion: vmc.Vec4{X: 2.1, Y: 2.2, Z: 2.3, W: 2.4},
@@ -210,6 +212,8 @@ func TestParseDeviceTransform(t *testing.T) {
 		t,
 		[]byte("/VMC/Ext/Con/Pos\x00\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a"),
 		&vmc.DeviceTransform{
+			Device:     vmc.DeviceTypeController,
+			Local:      false,
 			Serial:     []byte("tst"),
 			Position:   vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3},
 			Quaternion: vmc.Vec4{X: 2.1, Y: 2.2, Z: 2.3, W: 2.4},
@@ -219,6 +223,8 @@ func TestParseDeviceTransform(t *testing.T) {
 		t,
 		[]byte("/VMC/Ext/Tra/Pos\x00\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a"),
 		&vmc.DeviceTransform{
+			Device:     vmc.DeviceTypeTracker,
+			Local:      false,
 			Serial:     []byte("tst"),
 			Position:   vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3},
 			Quaternion: vmc.Vec4{X: 2.1, Y: 2.2, Z: 2.3, W: 2.4},
@@ -229,6 +235,8 @@ func TestParseDeviceTransform(t *testing.T) {
 		t,
 		[]byte("/VMC/Ext/Hmd/Pos/Local\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a"),
 		&vmc.DeviceTransform{
+			Device:     vmc.DeviceTypeHmd,
+			Local:      true,
 			Serial:     []byte("tst"),
 			Position:   vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3},
 			Quaternion: vmc.Vec4{X: 2.1, Y: 2.2, Z: 2.3, W: 2.4},
@@ -238,6 +246,8 @@ func TestParseDeviceTransform(t *testing.T) {
 		t,
 		[]byte("/VMC/Ext/Con/Pos/Local\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a"),
 		&vmc.DeviceTransform{
+			Device:     vmc.DeviceTypeController,
+			Local:      true,
 			Serial:     []byte("tst"),
 			Position:   vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3},
 			Quaternion: vmc.Vec4{X: 2.1, Y: 2.2, Z: 2.3, W: 2.4},
@@ -247,6 +257,8 @@ func TestParseDeviceTransform(t *testing.T) {
 		t,
 		[]byte("/VMC/Ext/Tra/Pos/Local\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a"),
 		&vmc.DeviceTransform{
+			Device:     vmc.DeviceTypeTracker,
+			Local:      true,
 			Serial:     []byte("tst"),
 			Position:   vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3},
 			Quaternion: vmc.Vec4{X: 2.1, Y: 2.2, Z: 2.3, W: 2.4},
@@ -261,19 +273,19 @@ func TestParseReceiveEnable(t *testing.T) {
 		&vmc.ReceiveEnable{
 			Enable:    true,
 			Port:      8080,
-			IPAddress: nil,
+			IPAddress: vmc.None[[]byte](),
+			Version:   vmc.ProtocolV2_4,
 		},
 	)
 
-	ipAddress := []byte("127.0.0.1")
-
 	assertMessage(
 		t,
 		[]byte("/VMC/Ext/Rcv\x00\x00\x00\x00,iis\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x1f\x90127.0.0.1\x00\x00\x00"),
 		&vmc.ReceiveEnable{
 			Enable:    true,
 			Port:      8080,
-			IPAddress: &ipAddress,
+			IPAddress: vmc.Some([]byte("127.0.0.1")),
+			Version:   vmc.ProtocolV2_7,
 		},
 	)
 }
@@ -296,21 +308,21 @@ func TestParseLocalVrm(t *testing.T) {
 		t,
 		[]byte("/VMC/Ext/VRM\x00\x00\x00\x00,ss\x00t01\x00t02\x00"),
 		&vmc.LocalVrm{
-			Path:  []byte("t01"),
-			Title: []byte("t02"),
-			Hash:  nil,
+			Path:    []byte("t01"),
+			Title:   []byte("t02"),
+			Hash:    vmc.None[[]byte](),
+			Version: vmc.ProtocolV2_4,
 		},
 	)
 
-	hash := []byte("t03")
-
 	assertMessage(
 		t,
 		[]byte("/VMC/Ext/VRM\x00\x00\x00\x00,sss\x00\x00\x00\x00t01\x00t02\x00t03\x00"),
 		&vmc.LocalVrm{
-			Path:  []byte("t01"),
-			Title: []byte("t02"),
-			Hash:  &hash,
+			Path:    []byte("t01"),
+			Title:   []byte("t02"),
+			Hash:    vmc.Some([]byte("t03")),
+			Version: vmc.ProtocolV2_7,
 		},
 	)
 }
@@ -368,3 +380,14 @@ func TestParseLoadedSettingPath(t *testing.T) {
 		},
 	)
 }
+
+func TestParseEyeTarget(t *testing.T) {
+	assertMessage(
+		t,
+		[]byte("/VMC/Ext/Set/Eye\x00\x00\x00\x00,ifff\x00\x00\x00\x00\x00\x00\x01\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66"),
+		&vmc.EyeTarget{
+			Enable:   true,
+			Position: vmc.Vec3{X: 1.1, Y: 1.2, Z: 1.3},
+		},
+	)
+}
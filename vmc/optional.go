@@ -0,0 +1,49 @@
+package vmc
+
+import "fmt"
+
+// Optional is a value that might not be present. It's used for message fields, that were only
+// introduced in later versions of the protocol.
+//
+// Unlike a pointer, it doesn't require a separate allocation, and can be copied and compared like
+// the contained value itself.
+type Optional[T any] struct {
+	Value T    // Value is the contained value, only meaningful if Valid is true.
+	Valid bool // Valid tells whether the value is present.
+}
+
+var _ fmt.Stringer = (*Optional[int])(nil)
+
+// Some creates an optional with the value being present.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{Value: value, Valid: true}
+}
+
+// None creates an optional without a value.
+func None[T any]() Optional[T] {
+	var value T
+
+	return Optional[T]{Value: value, Valid: false}
+}
+
+// Get returns the contained value, and whether it was present at all.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Valid
+}
+
+// Or returns the contained value if present, or the fallback value otherwise.
+func (o Optional[T]) Or(fallback T) T {
+	if o.Valid {
+		return o.Value
+	}
+
+	return fallback
+}
+
+func (o Optional[T]) String() string {
+	if !o.Valid {
+		return "<none>"
+	}
+
+	return fmt.Sprint(o.Value)
+}
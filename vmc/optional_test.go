@@ -0,0 +1,26 @@
+package vmc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	some := vmc.Some(vmc.CalibrationModeMrFloorFix)
+	value, ok := some.Get()
+	assert.True(t, ok)
+	assert.Equal(t, vmc.CalibrationModeMrFloorFix, value)
+	assert.Equal(t, vmc.CalibrationModeMrFloorFix, some.Or(vmc.CalibrationModeNormal))
+	assert.Equal(t, "MrFloorFix", some.String())
+
+	none := vmc.None[vmc.CalibrationMode]()
+	_, ok = none.Get()
+	assert.False(t, ok)
+	assert.Equal(t, vmc.CalibrationModeNormal, none.Or(vmc.CalibrationModeNormal))
+	assert.Equal(t, "<none>", none.String())
+
+	assert.True(t, vmc.Some(1) == vmc.Some(1))
+	assert.False(t, vmc.Some(0) == vmc.None[int]())
+}
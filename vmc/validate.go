@@ -0,0 +1,236 @@
+package vmc
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// DefaultQuaternionTolerance is the allowed deviation of a quaternion's norm from 1, if a Validator
+// doesn't define its own tolerance.
+const DefaultQuaternionTolerance = 0.01
+
+// Issue is a single semantic problem with one of the fields of a message.
+type Issue struct {
+	Field  string // Field is the name of the affected field.
+	Reason string // Reason describes the problem.
+}
+
+func (i Issue) String() string {
+	return i.Field + ": " + i.Reason
+}
+
+// ValidationError reports all semantic issues, that a Validator found in a message. The message is
+// kept in the error, so callers can decide to repair it instead of dropping it.
+type ValidationError struct {
+	Message Message // Message is the invalid message.
+	Issues  []Issue // Issues are all the found problems, in field order.
+}
+
+var _ error = (*ValidationError)(nil)
+
+func (e ValidationError) Error() string {
+	issues := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		issues = append(issues, issue.String())
+	}
+
+	return fmt.Sprintf("invalid %T: %s", e.Message, strings.Join(issues, ", "))
+}
+
+// Validator checks messages for semantic issues, that can't be detected during parsing. Parsing
+// accepts any value that is encoded correctly, but many values make no sense for the VMC protocol:
+//
+//   - Floats that are NaN or infinite.
+//   - Quaternions that aren't normalized.
+//   - Empty bone, blend shape or device names.
+//   - Values outside their defined ranges, like blend shape values outside of 0..1, MIDI notes
+//     above 127, invalid port numbers or a non-positive field of view.
+//
+// The zero value is ready to use.
+type Validator struct {
+	// QuaternionTolerance is the allowed deviation of a quaternion's norm from 1. If zero,
+	// DefaultQuaternionTolerance is used.
+	QuaternionTolerance float32
+}
+
+// Validate checks the message with a default Validator.
+func Validate(msg Message) error {
+	var validator Validator
+
+	return validator.Validate(msg)
+}
+
+// Validate checks the message for semantic issues. It returns a ValidationError, that lists all
+// found issues, or nil if the message is valid. Messages that aren't part of the VMC protocol are
+// always valid.
+func (v *Validator) Validate(msg Message) error {
+	c := checker{tolerance: v.QuaternionTolerance, issues: nil}
+	if c.tolerance == 0 {
+		c.tolerance = DefaultQuaternionTolerance
+	}
+
+	switch m := msg.(type) {
+	case *RelativeTime:
+		c.atLeast("Time", m.Time, 0)
+	case *RootTransform:
+		c.name("Name", m.Name)
+		c.pose(m.Position, m.Quaternion)
+
+		if scale, ok := m.Scale.Get(); ok {
+			c.vec3("Scale", scale)
+		}
+
+		if offset, ok := m.Offset.Get(); ok {
+			c.vec3("Offset", offset)
+		}
+	case *BoneTransform:
+		c.name("Name", m.Name)
+		c.pose(m.Position, m.Quaternion)
+	case *BlendShapeProxyValue:
+		c.name("Name", m.Name)
+		c.between("Value", m.Value, 0, 1)
+	case *CameraTransform:
+		c.pose(m.Position, m.Quaternion)
+		c.fov(m.FOV)
+	case *ControllerInput:
+		c.name("Name", m.Name)
+		c.vec3("Axis", m.Axis)
+	case *MidiNoteInput:
+		c.intBetween("Channel", m.Channel, 0, 15)
+		c.intBetween("Note", m.Note, 0, 127)
+		c.between("Velocity", m.Velocity, 0, 1)
+	case *MidiCCValueInput:
+		c.intBetween("Knob", m.Knob, 0, 127)
+		c.between("Value", m.Value, 0, 1)
+	case *MidiCCButtonInput:
+		c.intBetween("Knob", m.Knob, 0, 127)
+	case *DeviceTransform:
+		c.name("Serial", m.Serial)
+		c.pose(m.Position, m.Quaternion)
+	case *ReceiveEnable:
+		c.intBetween("Port", m.Port, 1, math.MaxUint16)
+	case *DirectionalLight:
+		c.pose(m.Position, m.Quaternion)
+		c.color("Color", m.Color, math.MaxFloat32)
+	case *BackgroundColor:
+		c.color("Color", m.Color, 1)
+	case *EyeTarget:
+		c.vec3("Position", m.Position)
+	}
+
+	if len(c.issues) > 0 {
+		return ValidationError{Message: msg, Issues: c.issues}
+	}
+
+	return nil
+}
+
+// checker collects the issues of a single message.
+type checker struct {
+	tolerance float32
+	issues    []Issue
+}
+
+func (c *checker) report(field, format string, args ...interface{}) {
+	c.issues = append(c.issues, Issue{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (c *checker) name(field string, name []byte) {
+	if len(name) == 0 {
+		c.report(field, "empty name")
+	}
+}
+
+func (c *checker) finite(field string, value float32) bool {
+	if !isFinite(value) {
+		c.report(field, "%v is not a finite number", value)
+
+		return false
+	}
+
+	return true
+}
+
+func (c *checker) atLeast(field string, value, lower float32) {
+	if c.finite(field, value) && value < lower {
+		c.report(field, "%v is less than %v", value, lower)
+	}
+}
+
+func (c *checker) between(field string, value, lower, upper float32) {
+	if c.finite(field, value) && (value < lower || value > upper) {
+		c.report(field, "%v is outside of %v..%v", value, lower, upper)
+	}
+}
+
+func (c *checker) intBetween(field string, value, lower, upper int32) {
+	if value < lower || value > upper {
+		c.report(field, "%d is outside of %d..%d", value, lower, upper)
+	}
+}
+
+func (c *checker) fov(value float32) {
+	if c.finite("FOV", value) && (value <= 0 || value >= 180) {
+		c.report("FOV", "%v is outside of 0..180 (exclusive)", value)
+	}
+}
+
+func (c *checker) vec3(field string, value Vec3) bool {
+	return c.components(field, "XYZ", value.X, value.Y, value.Z)
+}
+
+func (c *checker) vec4(field string, value Vec4) bool {
+	return c.components(field, "XYZW", value.X, value.Y, value.Z, value.W)
+}
+
+// components checks that all components of a vector are finite. The names contain a single letter
+// for each component.
+func (c *checker) components(field, names string, values ...float32) bool {
+	valid := true
+
+	for i, value := range values {
+		if !isFinite(value) {
+			c.report(field+"."+names[i:i+1], "%v is not a finite number", value)
+			valid = false
+		}
+	}
+
+	return valid
+}
+
+// color checks the RGBA components of a color. Colors may exceed the upper bound of 1 for HDR
+// lighting, so it's configurable.
+func (c *checker) color(field string, value Vec4, upper float32) {
+	const names = "RGBA"
+
+	for i, component := range [...]float32{value.X, value.Y, value.Z, value.W} {
+		switch {
+		case !isFinite(component):
+			c.report(field+"."+names[i:i+1], "%v is not a finite number", component)
+		case component < 0 || component > upper:
+			c.report(field+"."+names[i:i+1], "%v is outside of 0..%v", component, upper)
+		}
+	}
+}
+
+func (c *checker) pose(position Vec3, quaternion Vec4) {
+	c.vec3("Position", position)
+
+	if !c.vec4("Quaternion", quaternion) {
+		return
+	}
+
+	norm := math.Sqrt(float64(quaternion.X*quaternion.X +
+		quaternion.Y*quaternion.Y +
+		quaternion.Z*quaternion.Z +
+		quaternion.W*quaternion.W))
+
+	if math.Abs(norm-1) > float64(c.tolerance) {
+		c.report("Quaternion", "norm %.4f deviates from 1", norm)
+	}
+}
+
+func isFinite(value float32) bool {
+	return !math.IsNaN(float64(value)) && !math.IsInf(float64(value), 0)
+}
@@ -0,0 +1,129 @@
+package vmc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	identity := vmc.Vec4{X: 0, Y: 0, Z: 0, W: 1}
+	nan := float32(math.NaN())
+
+	tests := []struct {
+		name   string
+		msg    vmc.Message
+		issues []vmc.Issue
+	}{
+		{
+			name: "valid bone",
+			msg:  &vmc.BoneTransform{Name: []byte("Hips"), Position: vmc.Vec3{X: 1, Y: 2, Z: 3}, Quaternion: identity},
+		},
+		{
+			name: "slightly off quaternion",
+			msg:  &vmc.BoneTransform{Name: []byte("Hips"), Quaternion: vmc.Vec4{X: 0, Y: 0, Z: 0, W: 1.005}},
+		},
+		{
+			name: "corrupt bone",
+			msg:  &vmc.BoneTransform{Name: nil, Position: vmc.Vec3{X: nan, Y: 0, Z: 0}, Quaternion: vmc.Vec4{X: 1, Y: 1, Z: 0, W: 0}},
+			issues: []vmc.Issue{
+				{Field: "Name", Reason: "empty name"},
+				{Field: "Position.X", Reason: "NaN is not a finite number"},
+				{Field: "Quaternion", Reason: "norm 1.4142 deviates from 1"},
+			},
+		},
+		{
+			name: "infinite root scale",
+			msg: &vmc.RootTransform{
+				Name:       []byte("root"),
+				Quaternion: identity,
+				Scale:      vmc.Some(vmc.Vec3{X: 1, Y: float32(math.Inf(1)), Z: 1}),
+				Offset:     vmc.None[vmc.Vec3](),
+			},
+			issues: []vmc.Issue{{Field: "Scale.Y", Reason: "+Inf is not a finite number"}},
+		},
+		{
+			name:   "blend shape out of range",
+			msg:    &vmc.BlendShapeProxyValue{Name: []byte("A"), Value: 1.5},
+			issues: []vmc.Issue{{Field: "Value", Reason: "1.5 is outside of 0..1"}},
+		},
+		{
+			name:   "negative port",
+			msg:    &vmc.ReceiveEnable{Enable: true, Port: -1, IPAddress: vmc.None[[]byte]()},
+			issues: []vmc.Issue{{Field: "Port", Reason: "-1 is outside of 1..65535"}},
+		},
+		{
+			name:   "zero fov",
+			msg:    &vmc.CameraTransform{Name: []byte("cam"), Quaternion: identity, FOV: 0},
+			issues: []vmc.Issue{{Field: "FOV", Reason: "0 is outside of 0..180 (exclusive)"}},
+		},
+		{
+			name: "midi note",
+			msg:  &vmc.MidiNoteInput{Active: true, Channel: 16, Note: 128, Velocity: 0.5},
+			issues: []vmc.Issue{
+				{Field: "Channel", Reason: "16 is outside of 0..15"},
+				{Field: "Note", Reason: "128 is outside of 0..127"},
+			},
+		},
+		{
+			name:   "background color",
+			msg:    &vmc.BackgroundColor{Color: vmc.Vec4{X: 1, Y: 0, Z: -0.5, W: 1}},
+			issues: []vmc.Issue{{Field: "Color.B", Reason: "-0.5 is outside of 0..1"}},
+		},
+		{
+			name: "hdr light",
+			msg:  &vmc.DirectionalLight{Name: []byte("sun"), Quaternion: identity, Color: vmc.Vec4{X: 2, Y: 2, Z: 2, W: 1}},
+		},
+		{
+			name: "unknown message",
+			msg:  &vmc.UnknownMessage{Address: []byte("/test")},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := vmc.Validate(tt.msg)
+			if tt.issues == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var validationErr vmc.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Same(t, tt.msg, validationErr.Message)
+			assert.Equal(t, tt.issues, validationErr.Issues)
+		})
+	}
+}
+
+func TestValidatorTolerance(t *testing.T) {
+	validator := vmc.Validator{QuaternionTolerance: 0.001}
+	msg := &vmc.BoneTransform{Name: []byte("Hips"), Quaternion: vmc.Vec4{X: 0, Y: 0, Z: 0, W: 1.005}}
+
+	assert.EqualError(t, validator.Validate(msg), "invalid *vmc.BoneTransform: Quaternion: norm 1.0050 deviates from 1")
+}
+
+func TestDecoderValidator(t *testing.T) {
+	decoder := vmc.Decoder{Validator: &vmc.Validator{}}
+	valid := []byte("/VMC/Ext/Blend/Val\x00\x00,sf\x00tst\x00\x3f\x80\x00\x00")
+
+	msg, err := decoder.Decode(valid)
+	require.NoError(t, err)
+	assert.Equal(t, &vmc.BlendShapeProxyValue{Name: []byte("tst"), Value: 1}, msg)
+
+	_, err = decoder.Decode([]byte("/VMC/Ext/Blend/Val\x00\x00,sf\x00tst\x00\x40\xa0\x00\x00"))
+
+	var validationErr vmc.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, &vmc.BlendShapeProxyValue{Name: []byte("tst"), Value: 5}, validationErr.Message)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = decoder.Decode(valid)
+	})
+	assert.Zero(t, allocs)
+}
@@ -1,5 +1,7 @@
 package vmc
 
+import "math"
+
 // Vec3 is a 3-dimensional coordinate.
 type Vec3 struct {
 	X float32
@@ -14,3 +16,124 @@ type Vec4 struct {
 	Z float32
 	W float32
 }
+
+// Add returns the component-wise sum of both vectors.
+func (v Vec3) Add(o Vec3) Vec3 {
+	return Vec3{X: v.X + o.X, Y: v.Y + o.Y, Z: v.Z + o.Z}
+}
+
+// Sub returns the component-wise difference of both vectors.
+func (v Vec3) Sub(o Vec3) Vec3 {
+	return Vec3{X: v.X - o.X, Y: v.Y - o.Y, Z: v.Z - o.Z}
+}
+
+// Scale multiplies all components with the factor.
+func (v Vec3) Scale(factor float32) Vec3 {
+	return Vec3{X: v.X * factor, Y: v.Y * factor, Z: v.Z * factor}
+}
+
+// Lerp linearly interpolates between v and o, where t=0 yields v and t=1 yields o.
+func (v Vec3) Lerp(o Vec3, t float32) Vec3 {
+	return v.Add(o.Sub(v).Scale(t))
+}
+
+// Dot returns the dot product of both vectors.
+func (v Vec4) Dot(o Vec4) float32 {
+	return v.X*o.X + v.Y*o.Y + v.Z*o.Z + v.W*o.W
+}
+
+// Scale multiplies all components with the factor.
+func (v Vec4) Scale(factor float32) Vec4 {
+	return Vec4{X: v.X * factor, Y: v.Y * factor, Z: v.Z * factor, W: v.W * factor}
+}
+
+// Length returns the euclidean norm of the vector.
+func (v Vec4) Length() float32 {
+	return float32(math.Sqrt(float64(v.Dot(v))))
+}
+
+// Normalize scales the vector to a length of 1. Zero vectors are returned as the identity
+// quaternion, as they can't be normalized.
+func (v Vec4) Normalize() Vec4 {
+	length := v.Length()
+	if length == 0 {
+		return Vec4{X: 0, Y: 0, Z: 0, W: 1}
+	}
+
+	return v.Scale(1 / length)
+}
+
+// Slerp spherically interpolates between the quaternions v and o, where t=0 yields v and t=1
+// yields o. It always takes the shortest path, and both quaternions are expected to be normalized.
+func (v Vec4) Slerp(o Vec4, t float32) Vec4 {
+	// Nearly identical rotations are interpolated linearly, to avoid a division by zero.
+	const linearThreshold = 0.9995
+
+	cos := v.Dot(o)
+	if cos < 0 {
+		o = o.Scale(-1)
+		cos = -cos
+	}
+
+	if cos > linearThreshold {
+		return Vec4{
+			X: v.X + (o.X-v.X)*t,
+			Y: v.Y + (o.Y-v.Y)*t,
+			Z: v.Z + (o.Z-v.Z)*t,
+			W: v.W + (o.W-v.W)*t,
+		}.Normalize()
+	}
+
+	theta := math.Acos(float64(cos))
+	sin := math.Sin(theta)
+	a := float32(math.Sin((1-float64(t))*theta) / sin)
+	b := float32(math.Sin(float64(t)*theta) / sin)
+
+	return Vec4{
+		X: v.X*a + o.X*b,
+		Y: v.Y*a + o.Y*b,
+		Z: v.Z*a + o.Z*b,
+		W: v.W*a + o.W*b,
+	}
+}
+
+// AxisAngle creates the quaternion for a rotation around the normalized axis, by the angle in
+// degrees.
+func AxisAngle(axis Vec3, angle float32) Vec4 {
+	half := float64(angle) * math.Pi / 360
+	sin := float32(math.Sin(half))
+
+	return Vec4{X: axis.X * sin, Y: axis.Y * sin, Z: axis.Z * sin, W: float32(math.Cos(half))}
+}
+
+// Mul returns the Hamilton product of the quaternions v and o, which is the rotation o followed
+// by the rotation v.
+func (v Vec4) Mul(o Vec4) Vec4 {
+	return Vec4{
+		X: v.W*o.X + v.X*o.W + v.Y*o.Z - v.Z*o.Y,
+		Y: v.W*o.Y - v.X*o.Z + v.Y*o.W + v.Z*o.X,
+		Z: v.W*o.Z + v.X*o.Y - v.Y*o.X + v.Z*o.W,
+		W: v.W*o.W - v.X*o.X - v.Y*o.Y - v.Z*o.Z,
+	}
+}
+
+// Conjugate returns the inverse rotation of a normalized quaternion.
+func (v Vec4) Conjugate() Vec4 {
+	return Vec4{X: -v.X, Y: -v.Y, Z: -v.Z, W: v.W}
+}
+
+// Rotate applies the rotation of the normalized quaternion v to the vector p.
+func (v Vec4) Rotate(p Vec3) Vec3 {
+	axis := Vec3{X: v.X, Y: v.Y, Z: v.Z}
+	t := axis.cross(p).Scale(2)
+
+	return p.Add(t.Scale(v.W)).Add(axis.cross(t))
+}
+
+func (v Vec3) cross(o Vec3) Vec3 {
+	return Vec3{
+		X: v.Y*o.Z - v.Z*o.Y,
+		Y: v.Z*o.X - v.X*o.Z,
+		Z: v.X*o.Y - v.Y*o.X,
+	}
+}
@@ -0,0 +1,67 @@
+package vmc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVec3Lerp(t *testing.T) {
+	a := vmc.Vec3{X: 0, Y: 2, Z: -4}
+	b := vmc.Vec3{X: 10, Y: 4, Z: 4}
+
+	assert.Equal(t, a, a.Lerp(b, 0))
+	assert.Equal(t, b, a.Lerp(b, 1))
+	assert.Equal(t, vmc.Vec3{X: 5, Y: 3, Z: 0}, a.Lerp(b, 0.5))
+}
+
+func TestVec4Normalize(t *testing.T) {
+	assert.Equal(t, vmc.Vec4{X: 0, Y: 0.6, Z: 0, W: 0.8}, vmc.Vec4{X: 0, Y: 3, Z: 0, W: 4}.Normalize())
+	assert.Equal(t, vmc.Vec4{X: 0, Y: 0, Z: 0, W: 1}, vmc.Vec4{}.Normalize())
+}
+
+func TestVec4Slerp(t *testing.T) {
+	identity := vmc.Vec4{X: 0, Y: 0, Z: 0, W: 1}
+	quarter := vmc.Vec4{X: 0, Y: float32(math.Sqrt2 / 2), Z: 0, W: float32(math.Sqrt2 / 2)}
+
+	half := identity.Slerp(quarter, 0.5)
+	assert.InDelta(t, math.Sin(math.Pi/8), half.Y, 1e-6)
+	assert.InDelta(t, math.Cos(math.Pi/8), half.W, 1e-6)
+
+	// The negated quaternion describes the same rotation, so the result must be identical.
+	negated := identity.Slerp(quarter.Scale(-1), 0.5)
+	assert.InDelta(t, half.Y, negated.Y, 1e-6)
+	assert.InDelta(t, half.W, negated.W, 1e-6)
+
+	assert.Equal(t, identity, identity.Slerp(identity, 0.3))
+}
+
+func TestAxisAngle(t *testing.T) {
+	quarter := vmc.AxisAngle(vmc.Vec3{X: 0, Y: 1, Z: 0}, 90)
+	assert.InDelta(t, 0, quarter.X, 1e-6)
+	assert.InDelta(t, math.Sqrt2/2, quarter.Y, 1e-6)
+	assert.InDelta(t, 0, quarter.Z, 1e-6)
+	assert.InDelta(t, math.Sqrt2/2, quarter.W, 1e-6)
+
+	assert.Equal(t, vmc.Vec4{X: 0, Y: 0, Z: 0, W: 1}, vmc.AxisAngle(vmc.Vec3{X: 1, Y: 0, Z: 0}, 0))
+}
+
+func TestVec4Rotate(t *testing.T) {
+	// A quarter turn around the Y axis moves the forward direction to the right.
+	quarter := vmc.Vec4{X: 0, Y: float32(math.Sqrt2 / 2), Z: 0, W: float32(math.Sqrt2 / 2)}
+
+	rotated := quarter.Rotate(vmc.Vec3{X: 0, Y: 0, Z: 1})
+	assert.InDelta(t, 1, rotated.X, 1e-6)
+	assert.InDelta(t, 0, rotated.Z, 1e-6)
+
+	// Two quarter turns make a half turn, and the conjugate reverts the rotation.
+	rotated = quarter.Mul(quarter).Rotate(vmc.Vec3{X: 0, Y: 0, Z: 1})
+	assert.InDelta(t, -1, rotated.Z, 1e-6)
+
+	rotated = quarter.Conjugate().Rotate(quarter.Rotate(vmc.Vec3{X: 1, Y: 2, Z: 3}))
+	assert.InDelta(t, 1, rotated.X, 1e-6)
+	assert.InDelta(t, 2, rotated.Y, 1e-6)
+	assert.InDelta(t, 3, rotated.Z, 1e-6)
+}
@@ -0,0 +1,114 @@
+package vmc
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// ProtocolVersion is a version of the VMC protocol. Several messages were introduced in later
+// versions, or extended with additional arguments.
+//
+// The versions are ordered, so they can be compared directly to find the newer one.
+type ProtocolVersion uint8
+
+// Known versions of the VMC protocol.
+const (
+	ProtocolVersionUnknown ProtocolVersion = iota
+	ProtocolV1
+	ProtocolV2_0
+	ProtocolV2_1
+	ProtocolV2_2
+	ProtocolV2_3
+	ProtocolV2_4
+	ProtocolV2_5
+	ProtocolV2_6
+	ProtocolV2_7
+)
+
+var _ fmt.Stringer = (*ProtocolVersion)(nil)
+
+func (v ProtocolVersion) String() string {
+	switch v {
+	case ProtocolVersionUnknown:
+		return "Unknown"
+	case ProtocolV1:
+		return "V1"
+	case ProtocolV2_0:
+		return "V2.0"
+	case ProtocolV2_1:
+		return "V2.1"
+	case ProtocolV2_2:
+		return "V2.2"
+	case ProtocolV2_3:
+		return "V2.3"
+	case ProtocolV2_4:
+		return "V2.4"
+	case ProtocolV2_5:
+		return "V2.5"
+	case ProtocolV2_6:
+		return "V2.6"
+	case ProtocolV2_7:
+		return "V2.7"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(v))
+	}
+}
+
+// VersionTracker infers the protocol version a peer speaks, from the messages it sends. As older
+// receivers usually reject variants they don't know, senders can use it to pick the richest
+// variant of a message, that the peer still understands.
+//
+// The zero value is ready to use. It's safe for concurrent use, so a receiving and a sending
+// routine can share the same tracker.
+type VersionTracker struct {
+	version uint32
+}
+
+// Observe records the version of a message, received from the peer. It returns the highest version
+// seen so far.
+func (t *VersionTracker) Observe(msg Message) ProtocolVersion {
+	version := uint32(msg.ProtocolVersion())
+
+	for {
+		current := atomic.LoadUint32(&t.version)
+		if version <= current {
+			return ProtocolVersion(current)
+		}
+
+		if atomic.CompareAndSwapUint32(&t.version, current, version) {
+			return ProtocolVersion(version)
+		}
+	}
+}
+
+// Version returns the highest version observed so far, or ProtocolVersionUnknown if no message was
+// observed yet.
+func (t *VersionTracker) Version() ProtocolVersion {
+	return ProtocolVersion(atomic.LoadUint32(&t.version))
+}
+
+// Supports tells whether the peer is known to understand messages of the given version.
+func (t *VersionTracker) Supports(version ProtocolVersion) bool {
+	return t.Version() >= version
+}
+
+// Negotiate picks the version to use when sending messages to the peer. That is the highest
+// version both sides support. In case nothing was observed yet, the oldest version is picked, which
+// results in the most compatible message variants.
+func (t *VersionTracker) Negotiate(supported ProtocolVersion) ProtocolVersion {
+	version := t.Version()
+
+	switch {
+	case version == ProtocolVersionUnknown:
+		return ProtocolV1
+	case version < supported:
+		return version
+	default:
+		return supported
+	}
+}
+
+// Reset forgets all observed versions, for example when the peer reconnected.
+func (t *VersionTracker) Reset() {
+	atomic.StoreUint32(&t.version, uint32(ProtocolVersionUnknown))
+}
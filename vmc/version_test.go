@@ -0,0 +1,47 @@
+package vmc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageProtocolVersion(t *testing.T) {
+	tests := []struct {
+		input []byte
+		want  vmc.ProtocolVersion
+	}{
+		{[]byte("/VMC/Ext/OK\x00,i\x00\x00\x00\x00\x00\x01"), vmc.ProtocolV1},
+		{[]byte("/VMC/Ext/T\x00\x00,f\x00\x00\x40\xa0\x00\x00"), vmc.ProtocolV2_0},
+		{[]byte("/VMC/Ext/Midi/CC/Bit\x00\x00\x00\x00,ii\x00\x00\x00\x00\x01\x00\x00\x00\x01"), vmc.ProtocolV2_2},
+		{[]byte("/VMC/Ext/Hmd/Pos/Local\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a\x3f\xa6\x66\x66\x40\x06\x66\x66\x40\x0c\xcc\xcd\x40\x13\x33\x33\x40\x19\x99\x9a"), vmc.ProtocolV2_3},
+		{[]byte("/VMC/Ext/Rcv\x00\x00\x00\x00,iis\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x1f\x90127.0.0.1\x00\x00\x00"), vmc.ProtocolV2_7},
+	}
+
+	for _, test := range tests {
+		msg, err := vmc.ParseMessage(test.input)
+		require.NoError(t, err)
+		assert.Equal(t, test.want, msg.ProtocolVersion())
+	}
+}
+
+func TestVersionTracker(t *testing.T) {
+	var tracker vmc.VersionTracker
+
+	assert.Equal(t, vmc.ProtocolVersionUnknown, tracker.Version())
+	assert.Equal(t, vmc.ProtocolV1, tracker.Negotiate(vmc.ProtocolV2_7))
+
+	assert.Equal(t, vmc.ProtocolV2_1, tracker.Observe(&vmc.RootTransform{Version: vmc.ProtocolV2_1}))
+	assert.Equal(t, vmc.ProtocolV2_1, tracker.Observe(&vmc.BoneTransform{}))
+	assert.Equal(t, vmc.ProtocolV2_5, tracker.Observe(&vmc.Available{Version: vmc.ProtocolV2_5}))
+
+	assert.True(t, tracker.Supports(vmc.ProtocolV2_4))
+	assert.False(t, tracker.Supports(vmc.ProtocolV2_7))
+	assert.Equal(t, vmc.ProtocolV2_5, tracker.Negotiate(vmc.ProtocolV2_7))
+	assert.Equal(t, vmc.ProtocolV2_1, tracker.Negotiate(vmc.ProtocolV2_1))
+
+	tracker.Reset()
+	assert.Equal(t, vmc.ProtocolVersionUnknown, tracker.Version())
+}
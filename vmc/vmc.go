@@ -1,13 +1,18 @@
 // Package vmc implements parsing of "Virtual Motion Capture" messages.
 package vmc
 
+//go:generate go run github.com/dnaka91/go-vmcparser/internal/cmd/vmcgen -schema marionette.json -output marionette_gen.go -tests marionette_gen_test.go
+
 import (
 	"errors"
 	"fmt"
+
+	"github.com/dnaka91/go-vmcparser/osc"
 )
 
 // ErrUnknownAddress can happen during ParseMessage, if the message address describes either an
-// unknown/unsupported VMC message, or describes a non-VMC message.
+// unknown/unsupported VMC message, or describes a non-VMC message. ParseMessageUnknown and a
+// Decoder with PassUnknown enabled return an UnknownMessage instead.
 var ErrUnknownAddress = errors.New("unknown address")
 
 // ErrFiltered happens when a list of address filters is passed to ParseMessage, and the given raw
@@ -47,6 +52,8 @@ func (e InvalidEnumValueError) Error() string {
 	return fmt.Sprintf("invalid value for %v: %v", e.Name, e.Value)
 }
 
+// InvalidBufferLengthError happens during VMC message parsing, if the remaining data is too short
+// to contain the expected arguments.
 type InvalidBufferLengthError struct {
 	Length   int
 	Expected int
@@ -61,6 +68,28 @@ func (e InvalidBufferLengthError) Error() string {
 // Message is a marker for any type that is considered a VMC message.
 type Message interface {
 	isMessage()
+	// ProtocolVersion tells the protocol version of the message. For messages with several
+	// variants, it's the version of the received variant, otherwise the version that introduced
+	// the message.
+	ProtocolVersion() ProtocolVersion
+}
+
+// UnknownMessage is any message with an address, that is not part of the VMC protocol or not
+// supported yet. For example, several applications define their own extension messages.
+//
+// It's only returned by ParseMessageUnknown, or a Decoder that has PassUnknown enabled. The
+// arguments are decoded the same way as for osc.Message.
+type UnknownMessage struct {
+	Address   []byte        // Address is the message address.
+	TypeTags  []byte        // TypeTags contains the OSC type tags for each argument.
+	Arguments []interface{} // Arguments contains all parsed arguments.
+}
+
+func (u *UnknownMessage) isMessage() {}
+
+// ProtocolVersion is always unknown for unknown messages.
+func (u *UnknownMessage) ProtocolVersion() ProtocolVersion {
+	return ProtocolVersionUnknown
 }
 
 // ParseMessage takes a generic OSC message, and tries to parse it into one of the known VMC
@@ -72,73 +101,59 @@ type Message interface {
 // Parsing of a message can be limited with optional address filters. If passed, and the message's
 // address didn't match any of the filters, then all further processing is stopped and a ErrFiltered
 // error is returned.
+//
+// Any errors, that happen while parsing the message content, are returned as osc.ParseError that
+// describes the location of the failure.
+//
+// Messages with an unknown address fail with ErrUnknownAddress. To pass them through as
+// UnknownMessage instead, use ParseMessageUnknown.
 func ParseMessage(data []byte, addressFilters ...string) (Message, error) {
-	address, newData, err := getString(data)
-	if err != nil {
-		return nil, err
+	var decoder Decoder
+
+	return decoder.Decode(data, addressFilters...)
+}
+
+// ParseMessageUnknown works like ParseMessage, but returns messages with an unknown address as
+// UnknownMessage, instead of failing with ErrUnknownAddress. Its arguments are decoded generically,
+// and errors while doing so are returned as osc.ParseError.
+func ParseMessageUnknown(data []byte, addressFilters ...string) (Message, error) {
+	decoder := Decoder{
+		PassUnknown: true,
+		Validator:   nil,
+		parsers:     nil,
+		unknown:     nil,
+		messages:    messageStorage{},
 	}
-	data = newData
 
-	if !filterAddress(address, addressFilters) {
-		return nil, ErrFiltered
+	return decoder.Decode(data, addressFilters...)
+}
+
+// argumentError wraps an error that happened while parsing the arguments of a message, and locates
+// the failure as precisely as possible.
+func argumentError(raw, address, tags, data []byte, err error) error {
+	offset := len(raw) - len(data)
+	argument := -1
+
+	var parseErr osc.ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.Offset += offset
+		parseErr.Address = string(address)
+
+		return parseErr
 	}
 
-	tags, newData, err := getTypeTags(data)
-	if err != nil {
-		return nil, err
+	if remaining, ok := remainingData(err); ok {
+		pos := len(data) - remaining
+		offset += pos
+		argument = argumentIndex(tags, data, pos)
 	}
-	data = newData
-
-	switch string(address) {
-	case AddressAvailable:
-		return parseAvailable(tags, data)
-	case AddressRelativeTime:
-		return parseRelativeTime(tags, data)
-	case AddressRootTransform:
-		return parseRootTransform(tags, data)
-	case AddressBoneTransform:
-		return parseBoneTransform(tags, data)
-	case AddressBlendShapeProxyValue:
-		return parseBlendShapeProxyValue(tags, data)
-	case AddressBlendShapeProxyApply:
-		return parseBlendShapeProxyApply(tags, data)
-	case AddressCameraTransform:
-		return parseCameraTransform(tags, data)
-	case AddressControllerInput:
-		return parseControllerInput(tags, data)
-	case AddressKeyboardInput:
-		return parseKeyboardInput(tags, data)
-	case AddressMidiNoteInput:
-		return parseMidiNoteInput(tags, data)
-	case AddressMidiCCValueInput:
-		return parseMidiCCValueInput(tags, data)
-	case AddressMidiCCButtonInput:
-		return parseMidiCCButtonInput(tags, data)
-	case AddressDeviceTransformHmd,
-		AddressDeviceTransformCon,
-		AddressDeviceTransformTra,
-		AddressDeviceTransformHmdLocal,
-		AddressDeviceTransformConLocal,
-		AddressDeviceTransformTraLocal:
-		return parseDeviceTransform(tags, data)
-	case AddressReceiveEnable:
-		return parseReceiveEnable(tags, data)
-	case AddressDirectionalLight:
-		return parseDirectionalLight(tags, data)
-	case AddressLocalVrm:
-		return parseLocalVrm(tags, data)
-	case AddressRemoteVrm:
-		return parseRemoteVrm(tags, data)
-	case AddressOptionString:
-		return parseOptionString(tags, data)
-	case AddressBackgroundColor:
-		return parseBackgroundColor(tags, data)
-	case AddressWindowAttribute:
-		return parseWindowAttribute(tags, data)
-	case AddressLoadedSettingPath:
-		return parseLoadedSettingPath(tags, data)
-	default:
-		return nil, ErrUnknownAddress
+
+	return osc.ParseError{
+		Offset:   offset,
+		Argument: argument,
+		Path:     nil,
+		Address:  string(address),
+		Err:      err,
 	}
 }
 
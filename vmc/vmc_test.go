@@ -0,0 +1,39 @@
+package vmc_test
+
+import (
+	"testing"
+
+	"github.com/dnaka91/go-vmcparser/osc"
+	"github.com/dnaka91/go-vmcparser/vmc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseErrorBufferLength(t *testing.T) {
+	_, err := vmc.ParseMessage([]byte("/VMC/Ext/Bone/Pos\x00\x00\x00,sfffffff\x00\x00\x00tst\x00\x3f\x8c\xcc\xcd\x3f\x99\x99\x9a"))
+
+	var lengthErr vmc.InvalidBufferLengthError
+	assert.ErrorAs(t, err, &lengthErr)
+	assert.Equal(t, vmc.InvalidBufferLengthError{Length: 8, Expected: 28}, lengthErr)
+
+	var parseErr osc.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 36, parseErr.Offset)
+	assert.Equal(t, 1, parseErr.Argument)
+	assert.Equal(t, vmc.AddressBoneTransform, parseErr.Address)
+}
+
+func TestParseErrorString(t *testing.T) {
+	_, err := vmc.ParseMessage([]byte("/VMC/Ext/VRM\x00\x00\x00\x00,ss\x00t01\x00t02"))
+	assert.ErrorIs(t, err, osc.ErrStringMissingTerminator)
+
+	var parseErr osc.ParseError
+	assert.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, 24, parseErr.Offset)
+	assert.Equal(t, 1, parseErr.Argument)
+	assert.Equal(t, vmc.AddressLocalVrm, parseErr.Address)
+}
+
+func TestParseErrorUnknownAddress(t *testing.T) {
+	_, err := vmc.ParseMessage([]byte("/unknown\x00\x00\x00\x00,\x00\x00\x00"))
+	assert.Equal(t, vmc.ErrUnknownAddress, err)
+}